@@ -122,4 +122,8 @@ func main() {
 	if status := *test.TestCommand.Status; status > 0 {
 		os.Exit(int(status))
 	}
+
+	if status := *project.DeployCommand.Status; status > 0 {
+		os.Exit(status)
+	}
 }
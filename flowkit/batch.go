@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// maxSequenceNumberRetries is how many times a transaction rejected because of the proposal key sequence number is resent.
+const maxSequenceNumberRetries = 3
+
+// BatchTransaction is a transaction sent as part of a batch, using the accounts provided for each role.
+type BatchTransaction struct {
+	Accounts transactions.AccountRoles
+	Script   Script
+	GasLimit uint64
+}
+
+// BatchResult is the outcome of a transaction sent as part of a batch.
+//
+// Error is set if the transaction couldn't be built, sent or if waiting for the result failed,
+// while errors of executed transactions are set on the transaction result.
+type BatchResult struct {
+	Tx     *flow.Transaction
+	Result *flow.TransactionResult
+	Error  error
+}
+
+// SendTransactions sends the transactions to the Flow network and waits for their results, with at most concurrency
+// transactions in flight at the same time. Results are returned in the same order as the transactions.
+//
+// Each transaction is proposed using a key from a key pool of the proposer account, so concurrent transactions
+// don't conflict on sequence numbers. The number of transactions in flight for a proposer is limited by the number of
+// full weight keys on the proposer account that match the proposer key. Transactions rejected because of the sequence
+// number are resent with a refreshed key.
+func (f *Flowkit) SendTransactions(
+	ctx context.Context,
+	txs []BatchTransaction,
+	concurrency int,
+) ([]BatchResult, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency %d, must be at least 1", concurrency)
+	}
+	if concurrency > len(txs) {
+		concurrency = len(txs)
+	}
+
+	f.logger.StartProgress(fmt.Sprintf("Sending %d transactions...", len(txs)))
+	defer f.logger.StopProgress()
+
+	pools := &keyPools{gateway: f.gateway, pools: make(map[flow.Address]*KeyPool)}
+	results := make([]BatchResult, len(txs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = f.sendBatchTransaction(ctx, pools, txs[j])
+			}
+		}()
+	}
+
+	for i := range txs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// sendBatchTransaction sends the transaction using a key from the proposer key pool.
+func (f *Flowkit) sendBatchTransaction(ctx context.Context, pools *keyPools, batchTx BatchTransaction) BatchResult {
+	pool, err := pools.get(ctx, &batchTx.Accounts.Proposer)
+	if err != nil {
+		return BatchResult{Error: err}
+	}
+
+	for attempt := 0; ; attempt++ {
+		key, err := pool.Acquire(ctx)
+		if err != nil {
+			return BatchResult{Error: err}
+		}
+
+		tx, result, err := f.sendWithProposalKey(ctx, pool, key, batchTx)
+		pool.Release(key, err == nil && !isSequenceNumberError(result.Error))
+		if err != nil {
+			return BatchResult{Tx: tx, Error: err}
+		}
+
+		if isSequenceNumberError(result.Error) && attempt < maxSequenceNumberRetries {
+			continue
+		}
+
+		return BatchResult{Tx: tx, Result: result}
+	}
+}
+
+// sendWithProposalKey builds, signs and sends the transaction using the proposal key and waits for the result.
+func (f *Flowkit) sendWithProposalKey(
+	ctx context.Context,
+	pool *KeyPool,
+	key ProposalKey,
+	batchTx BatchTransaction,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	roles := batchTx.Accounts
+	roles.Proposer = *pool.Account(key)
+
+	tx, err := f.newTransaction(ctx, roles.AddressRoles(), batchTx.Script, batchTx.GasLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx.SetProposalKey(roles.Proposer.Address, key.Index, key.SequenceNumber)
+
+	for _, signer := range roles.Signers() {
+		if err := tx.SetSigner(signer); err != nil {
+			return nil, nil, err
+		}
+
+		tx, err = tx.Sign()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the result error is checked for sequence number errors, so the transaction must be executed
+	result, err := f.waitForExecutedResult(ctx, sentTx.ID())
+	if err != nil {
+		return sentTx, nil, err
+	}
+
+	return sentTx, result, nil
+}
+
+// keyPools creates a key pool for each proposer account the first time it is used.
+type keyPools struct {
+	gateway gateway.Gateway
+	mu      sync.Mutex
+	pools   map[flow.Address]*KeyPool
+}
+
+func (k *keyPools) get(ctx context.Context, proposer *accounts.Account) (*KeyPool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if pool, ok := k.pools[proposer.Address]; ok {
+		return pool, nil
+	}
+
+	pool, err := NewKeyPool(ctx, k.gateway, proposer)
+	if err != nil {
+		return nil, err
+	}
+	k.pools[proposer.Address] = pool
+
+	return pool, nil
+}
@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+
+	"golang.org/x/exp/slices"
 )
 
 // Config contains all the configuration for CLI and implements getters and setters for properties.
@@ -80,6 +82,18 @@ func (c *Config) Validate() error {
 		if _, err := c.Accounts.ByName(d.Account); err != nil {
 			return fmt.Errorf("deployment contains nonexisting account %s", d.Account)
 		}
+
+		for _, hook := range d.Hooks {
+			if hook.Contract != "" && !slices.ContainsFunc(d.Contracts, func(con ContractDeployment) bool {
+				return con.Name == hook.Contract
+			}) {
+				return fmt.Errorf("deployment hook %s runs with contract %s which is not part of the deployment", hook.Transaction, hook.Contract)
+			}
+
+			if _, err := c.Accounts.ByName(d.SignerOf(hook)); err != nil {
+				return fmt.Errorf("deployment hook %s contains nonexisting signer account %s", hook.Transaction, d.SignerOf(hook))
+			}
+		}
 	}
 
 	return nil
@@ -318,6 +318,40 @@ func TestConfig_Validate(t *testing.T) {
 
 	err = cfg.Validate()
 	assert.EqualError(t, err, "deployment contains nonexisting account no")
+
+	cfg = &config.Config{
+		Contracts: config.Contracts{{
+			Name:     "MyContract",
+			Location: "contracts/my-contract.cdc",
+		}},
+		Accounts: config.Accounts{{
+			Name:    "MyAccount",
+			Address: flow.HexToAddress("0x01"),
+		}},
+		Deployments: config.Deployments{{
+			Network: "testnet",
+			Contracts: []config.ContractDeployment{{
+				Name: "MyContract",
+			}},
+			Account: "MyAccount",
+			Hooks: []config.DeploymentHook{{
+				Transaction: "transactions/setup.cdc",
+				Contract:    "OtherContract",
+			}},
+		}},
+		Networks: config.DefaultNetworks,
+	}
+
+	err = cfg.Validate()
+	assert.EqualError(t, err, "deployment hook transactions/setup.cdc runs with contract OtherContract which is not part of the deployment")
+
+	cfg.Deployments[0].Hooks = []config.DeploymentHook{{
+		Transaction: "transactions/setup.cdc",
+		Signer:      "no",
+	}}
+
+	err = cfg.Validate()
+	assert.EqualError(t, err, "deployment hook transactions/setup.cdc contains nonexisting signer account no")
 }
 
 func Test_DefaultConfig(t *testing.T) {
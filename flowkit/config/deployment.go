@@ -31,11 +31,29 @@ type ContractDeployment struct {
 	Args []cadence.Value
 }
 
+// DeploymentHook defines a transaction run before or after contracts of the deployment are deployed.
+type DeploymentHook struct {
+	Transaction string          // location of the transaction
+	Args        []cadence.Value // transaction arguments
+	Signer      string          // account name signing the transaction, the deployment account if empty
+	Contract    string          // contract the hook runs with, if empty the hook runs with all contracts of the deployment
+	Before      bool            // whether the hook runs before the contracts are deployed instead of after
+}
+
 // Deployment defines the configuration for a contract deployment.
 type Deployment struct {
 	Network   string               // network name to deploy to
 	Account   string               // account name to which to deploy to
 	Contracts []ContractDeployment // contracts to deploy
+	Hooks     []DeploymentHook     // transactions to run before or after contracts are deployed
+}
+
+// SignerOf returns the account name signing the hook transaction.
+func (d *Deployment) SignerOf(hook DeploymentHook) string {
+	if hook.Signer == "" {
+		return d.Account
+	}
+	return hook.Signer
 }
 
 // AddContract to deployment list on the account name and network name.
@@ -50,12 +68,22 @@ func (d *Deployment) AddContract(contract ContractDeployment) {
 }
 
 // RemoveContract removes a specific contract by name from an existing deployment identified by account name and network name.
+//
+// Hooks running with the contract are removed as well.
 func (d *Deployment) RemoveContract(contractName string) {
 	for i, contract := range d.Contracts {
 		if contract.Name == contractName {
 			d.Contracts = slices.Delete(d.Contracts, i, i+1)
 		}
 	}
+
+	var hooks []DeploymentHook
+	for _, hook := range d.Hooks {
+		if hook.Contract != contractName {
+			hooks = append(hooks, hook)
+		}
+	}
+	d.Hooks = hooks
 }
 
 type Deployments []Deployment
@@ -92,6 +92,10 @@ func Test_Deployment(t *testing.T) {
 				Network:   net,
 				Account:   acc,
 				Contracts: copyContracts,
+				Hooks: []DeploymentHook{
+					{Transaction: "setup.cdc", Contract: contracts[0].Name},
+					{Transaction: "link.cdc"},
+				},
 			},
 		}
 
@@ -100,6 +104,7 @@ func Test_Deployment(t *testing.T) {
 		assert.Len(t, *deployments, 1)
 		assert.Len(t, (*deployments)[0].Contracts, 1)
 		assert.Equal(t, (*deployments)[0].Contracts[0], contracts[1])
+		assert.Equal(t, []DeploymentHook{{Transaction: "link.cdc"}}, (*deployments)[0].Hooks)
 	})
 
 	t.Run("Deployment by network", func(t *testing.T) {
@@ -19,6 +19,7 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -38,14 +39,14 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 	for networkName, deploys := range j {
 
 		var deploy config.Deployment
-		for accountName, contracts := range deploys {
+		for accountName, account := range deploys {
 			deploy = config.Deployment{
 				Network: networkName,
 				Account: accountName,
 			}
 
 			var contractDeploys []config.ContractDeployment
-			for _, contract := range contracts {
+			for _, contract := range account.contracts() {
 				if contract.simple != "" {
 					contractDeploys = append(
 						contractDeploys,
@@ -55,19 +56,9 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 						},
 					)
 				} else {
-					args := make([]cadence.Value, 0)
-					for _, arg := range contract.advanced.Args {
-						b, err := json.Marshal(arg)
-						if err != nil {
-							return nil, err
-						}
-
-						cadenceArg, err := jsoncdc.Decode(nil, b)
-						if err != nil {
-							return nil, err
-						}
-
-						args = append(args, cadenceArg)
+					args, err := transformArgsToConfig(contract.advanced.Args)
+					if err != nil {
+						return nil, err
 					}
 
 					contractDeploys = append(
@@ -80,7 +71,28 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 				}
 			}
 
+			var hooks []config.DeploymentHook
+			for _, hook := range account.advanced.Hooks {
+				if hook.Transaction == "" {
+					return nil, fmt.Errorf("deployment hook for account %s on network %s is missing the transaction", accountName, networkName)
+				}
+
+				args, err := transformArgsToConfig(hook.Args)
+				if err != nil {
+					return nil, err
+				}
+
+				hooks = append(hooks, config.DeploymentHook{
+					Transaction: hook.Transaction,
+					Args:        args,
+					Signer:      hook.Signer,
+					Contract:    hook.Contract,
+					Before:      hook.Before,
+				})
+			}
+
 			deploy.Contracts = contractDeploys
+			deploy.Hooks = hooks
 			deployments = append(deployments, deploy)
 		}
 	}
@@ -88,6 +100,47 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 	return deployments, nil
 }
 
+// transformArgsToConfig decodes the JSON-Cadence arguments.
+func transformArgsToConfig(jsonArgs []map[string]any) ([]cadence.Value, error) {
+	args := make([]cadence.Value, 0)
+	for _, arg := range jsonArgs {
+		b, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		cadenceArg, err := jsoncdc.Decode(nil, b)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, cadenceArg)
+	}
+
+	return args, nil
+}
+
+// transformArgsToJSON encodes the arguments in the JSON-Cadence format used by the configuration.
+func transformArgsToJSON(configArgs []cadence.Value) []map[string]any {
+	args := make([]map[string]any, 0)
+	for _, arg := range configArgs {
+		switch arg.Type().ID() {
+		case "Bool":
+			args = append(args, map[string]any{
+				"type":  arg.Type().ID(),
+				"value": arg.ToGoValue(),
+			})
+		default:
+			args = append(args, map[string]any{
+				"type":  arg.Type().ID(),
+				"value": fmt.Sprintf("%v", arg.ToGoValue()),
+			})
+		}
+	}
+
+	return args
+}
+
 // transformToJSON transforms config structure to json structures for saving.
 func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeployments {
 	jsonDeploys := jsonDeployments{}
@@ -101,36 +154,44 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 					simple: c.Name,
 				})
 			} else {
-				args := make([]map[string]any, 0)
-				for _, arg := range c.Args {
-					switch arg.Type().ID() {
-					case "Bool":
-						args = append(args, map[string]any{
-							"type":  arg.Type().ID(),
-							"value": arg.ToGoValue(),
-						})
-					default:
-						args = append(args, map[string]any{
-							"type":  arg.Type().ID(),
-							"value": fmt.Sprintf("%v", arg.ToGoValue()),
-						})
-					}
-				}
-
 				deployments = append(deployments, deployment{
 					advanced: contractDeployment{
 						Name: c.Name,
-						Args: args,
+						Args: transformArgsToJSON(c.Args),
 					},
 				})
 			}
 		}
 
+		account := accountDeployment{simple: deployments}
+		if len(d.Hooks) > 0 {
+			hooks := make([]deploymentHook, 0, len(d.Hooks))
+			for _, hook := range d.Hooks {
+				jsonHook := deploymentHook{
+					Transaction: hook.Transaction,
+					Signer:      hook.Signer,
+					Contract:    hook.Contract,
+					Before:      hook.Before,
+				}
+				if len(hook.Args) > 0 {
+					jsonHook.Args = transformArgsToJSON(hook.Args)
+				}
+				hooks = append(hooks, jsonHook)
+			}
+
+			account = accountDeployment{
+				advanced: advancedDeployment{
+					Contracts: deployments,
+					Hooks:     hooks,
+				},
+			}
+		}
+
 		if _, ok := jsonDeploys[d.Network]; ok {
-			jsonDeploys[d.Network][d.Account] = deployments
+			jsonDeploys[d.Network][d.Account] = account
 		} else {
 			jsonDeploys[d.Network] = jsonDeployment{
-				d.Account: deployments,
+				d.Account: account,
 			}
 		}
 
@@ -149,7 +210,7 @@ type deployment struct {
 	advanced contractDeployment
 }
 
-type jsonDeployment map[string][]deployment
+type jsonDeployment map[string]accountDeployment
 
 func (d *deployment) UnmarshalJSON(b []byte) error {
 
@@ -196,3 +257,82 @@ func (j deployment) JSONSchema() *jsonschema.Schema {
 		},
 	}
 }
+
+// deploymentHook is a transaction run before or after the contracts are deployed.
+type deploymentHook struct {
+	Transaction string           `json:"transaction"`
+	Args        []map[string]any `json:"args,omitempty"`
+	Signer      string           `json:"signer,omitempty"`
+	Contract    string           `json:"contract,omitempty"`
+	Before      bool             `json:"before,omitempty"`
+}
+
+type advancedDeployment struct {
+	Contracts []deployment     `json:"contracts"`
+	Hooks     []deploymentHook `json:"hooks,omitempty"`
+}
+
+// accountDeployment is the list of contracts deployed to the account in the simple format,
+// or an object containing the contracts and the deployment hooks in the advanced format.
+type accountDeployment struct {
+	simple   []deployment
+	advanced advancedDeployment
+}
+
+func (a accountDeployment) contracts() []deployment {
+	if a.simple != nil {
+		return a.simple
+	}
+	return a.advanced.Contracts
+}
+
+func (a *accountDeployment) UnmarshalJSON(b []byte) error {
+
+	// simple format
+	var simple []deployment
+	err := json.Unmarshal(b, &simple)
+	if err == nil {
+		a.simple = simple
+		return nil
+	}
+
+	// only objects can be in the advanced format, report other values as invalid simple format
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		return err
+	}
+
+	// advanced format
+	var advanced advancedDeployment
+	err = json.Unmarshal(b, &advanced)
+	if err == nil {
+		a.advanced = advanced
+	} else {
+		return err
+	}
+
+	return nil
+}
+
+func (a accountDeployment) MarshalJSON() ([]byte, error) {
+	if len(a.advanced.Hooks) == 0 {
+		return json.Marshal(a.contracts())
+	}
+	return json.Marshal(a.advanced)
+}
+
+func (a accountDeployment) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type:  "array",
+				Items: &jsonschema.Schema{Ref: "#/$defs/deployment"},
+			},
+			{
+				Ref: "#/$defs/advancedDeployment",
+			},
+		},
+		Definitions: map[string]*jsonschema.Schema{
+			"advancedDeployment": jsonschema.Reflect(advancedDeployment{}),
+		},
+	}
+}
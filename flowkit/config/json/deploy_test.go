@@ -151,3 +151,53 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, "KittyItemsMarket", alice.Contracts[1].Name)
 	assert.Len(t, alice.Contracts[1].Args, 0)
 }
+
+func Test_DeploymentHooks(t *testing.T) {
+	b := []byte(`{
+		"testnet": {
+			"admin": {
+				"contracts": ["Token", "Market"],
+				"hooks": [
+					{
+						"transaction": "./transactions/setup_admin.cdc",
+						"args": [{ "type": "String", "value": "Hello World" }],
+						"contract": "Token"
+					},
+					{ "transaction": "./transactions/link.cdc", "signer": "user" },
+					{ "transaction": "./transactions/pause.cdc", "before": true }
+				]
+			},
+			"user": ["Kibble"]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	require.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	require.NoError(t, err)
+
+	admin := deployments.ByAccountAndNetwork("admin", "testnet")
+	require.NotNil(t, admin)
+	require.Len(t, admin.Contracts, 2)
+	assert.Equal(t, "Token", admin.Contracts[0].Name)
+	require.Len(t, admin.Hooks, 3)
+	assert.Equal(t, "./transactions/setup_admin.cdc", admin.Hooks[0].Transaction)
+	assert.Equal(t, "Token", admin.Hooks[0].Contract)
+	assert.Equal(t, `"Hello World"`, admin.Hooks[0].Args[0].String())
+	assert.Equal(t, "admin", admin.SignerOf(admin.Hooks[0]))
+	assert.Equal(t, "", admin.Hooks[1].Contract)
+	assert.Equal(t, "user", admin.SignerOf(admin.Hooks[1]))
+	assert.False(t, admin.Hooks[1].Before)
+	assert.True(t, admin.Hooks[2].Before)
+
+	user := deployments.ByAccountAndNetwork("user", "testnet")
+	require.NotNil(t, user)
+	assert.Len(t, user.Hooks, 0)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+}
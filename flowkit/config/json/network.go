@@ -22,7 +22,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/invopop/jsonschema"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -37,25 +40,74 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	networks := make(config.Networks, 0)
 
 	for networkName, n := range j {
-		if n.Advanced.Key != "" && n.Advanced.Host != "" {
-			err := validateECDSAP256Pub(n.Advanced.Key)
-			if err != nil {
-				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
-			}
-
-			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Advanced.Host,
-				Key:  n.Advanced.Key,
-			})
-		} else if n.Simple.Host != "" {
+		if n.Simple.Host != "" {
 			networks = append(networks, config.Network{
 				Name: networkName,
 				Host: n.Simple.Host,
 			})
-		} else {
+			continue
+		}
+
+		// advanced format must define a key unless it's used to provide other network options
+		if n.Advanced.Host == "" || (n.Advanced.Key == "" && !n.Advanced.hasOptions()) {
 			return nil, fmt.Errorf("failed to transform networks configuration")
 		}
+
+		if n.Advanced.Key != "" {
+			err := validateECDSAP256Pub(n.Advanced.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+			}
+		}
+
+		retry, err := n.Advanced.Retry.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry configuration for network with name %s: %w", networkName, err)
+		}
+
+		tls, err := n.Advanced.TLS.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls configuration for network with name %s: %w", networkName, err)
+		}
+		if tls.IsEnabled() && n.Advanced.Key != "" {
+			return nil, fmt.Errorf("invalid tls configuration for network with name %s: can't be used together with the network key", networkName)
+		}
+
+		rateLimit, err := n.Advanced.RateLimit.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit for network with name %s: %w", networkName, err)
+		}
+
+		cache, err := n.Advanced.Cache.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache configuration for network with name %s: %w", networkName, err)
+		}
+
+		headers, err := transformHeadersToConfig(n.Advanced.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid headers for network with name %s: %w", networkName, err)
+		}
+
+		policy := config.EndpointPolicy(n.Advanced.Policy)
+		if policy != "" && policy != config.FailoverPolicy && policy != config.RoundRobinPolicy {
+			return nil, fmt.Errorf(
+				"invalid policy %s for network with name %s, must be %s or %s",
+				n.Advanced.Policy, networkName, config.FailoverPolicy, config.RoundRobinPolicy,
+			)
+		}
+
+		networks = append(networks, config.Network{
+			Name:      networkName,
+			Host:      n.Advanced.Host,
+			Key:       n.Advanced.Key,
+			Retry:     retry,
+			Endpoints: n.Advanced.Endpoints,
+			Policy:    policy,
+			TLS:       tls,
+			Headers:   headers,
+			RateLimit: rateLimit,
+			Cache:     cache,
+		})
 	}
 
 	return networks, nil
@@ -66,7 +118,7 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	jsonNetworks := jsonNetworks{}
 
 	for _, n := range networks {
-		if n.Key != "" {
+		if isAdvancedNetwork(n) {
 			jsonNetworks[n.Name] = transformAdvancedNetworkToJSON(n)
 		} else {
 			jsonNetworks[n.Name] = transformSimpleNetworkToJSON(n)
@@ -76,6 +128,18 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	return jsonNetworks
 }
 
+// isAdvancedNetwork checks whether the network defines a key or other options which require the advanced format.
+func isAdvancedNetwork(n config.Network) bool {
+	return n.Key != "" ||
+		n.Retry != (config.NetworkRetry{}) ||
+		len(n.Endpoints) > 0 ||
+		n.Policy != "" ||
+		n.TLS.IsEnabled() ||
+		len(n.Headers) > 0 ||
+		n.RateLimit != (config.NetworkRateLimit{}) ||
+		n.Cache != (config.NetworkCache{})
+}
+
 func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
 	return jsonNetwork{
 		Simple: simpleNetwork{
@@ -87,12 +151,87 @@ func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
 func transformAdvancedNetworkToJSON(n config.Network) jsonNetwork {
 	return jsonNetwork{
 		Advanced: advancedNetwork{
-			Host: n.Host,
-			Key:  n.Key,
+			Host:      n.Host,
+			Key:       n.Key,
+			Retry:     transformRetryToJSON(n.Retry),
+			Endpoints: n.Endpoints,
+			Policy:    string(n.Policy),
+			TLS:       transformTLSToJSON(n.TLS),
+			Headers:   transformHeadersToJSON(n.Headers),
+			RateLimit: transformRateLimitToJSON(n.RateLimit),
+			Cache:     transformCacheToJSON(n.Cache),
 		},
 	}
 }
 
+func transformRetryToJSON(r config.NetworkRetry) *networkRetry {
+	if r == (config.NetworkRetry{}) {
+		return nil
+	}
+
+	retry := &networkRetry{MaxAttempts: r.MaxAttempts}
+	if r.MinBackoff != 0 {
+		retry.MinBackoff = r.MinBackoff.String()
+	}
+	if r.MaxBackoff != 0 {
+		retry.MaxBackoff = r.MaxBackoff.String()
+	}
+
+	return retry
+}
+
+func transformRateLimitToJSON(r config.NetworkRateLimit) *networkRateLimit {
+	if r == (config.NetworkRateLimit{}) {
+		return nil
+	}
+
+	return &networkRateLimit{
+		RequestsPerSecond: r.RequestsPerSecond,
+		Burst:             r.Burst,
+	}
+}
+
+func transformCacheToJSON(c config.NetworkCache) *networkCache {
+	if c == (config.NetworkCache{}) {
+		return nil
+	}
+
+	return &networkCache{
+		Disk:        c.Disk,
+		MaxDiskSize: c.MaxDiskSize,
+	}
+}
+
+func transformTLSToJSON(t *config.NetworkTLS) *networkTLS {
+	if !t.IsEnabled() {
+		return nil
+	}
+
+	return &networkTLS{
+		CAFile:     t.CAFile,
+		CertFile:   t.CertFile,
+		KeyFile:    t.KeyFile,
+		ServerName: t.ServerName,
+	}
+}
+
+func transformHeadersToJSON(headers []config.NetworkHeader) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	jsonHeaders := make(map[string]string, len(headers))
+	for _, h := range headers {
+		value := h.Value
+		if h.Env != "" {
+			value = h.Env // if we used env vars then use it when saving
+		}
+		jsonHeaders[h.Name] = value
+	}
+
+	return jsonHeaders
+}
+
 type jsonNetwork struct {
 	Simple   simpleNetwork
 	Advanced advancedNetwork
@@ -103,8 +242,174 @@ type simpleNetwork struct {
 }
 
 type advancedNetwork struct {
-	Host string `json:"host"`
-	Key  string `json:"key"`
+	Host      string            `json:"host"`
+	Key       string            `json:"key,omitempty"`
+	Retry     *networkRetry     `json:"retry,omitempty"`
+	Endpoints []string          `json:"endpoints,omitempty"`
+	Policy    string            `json:"policy,omitempty"`
+	TLS       *networkTLS       `json:"tls,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	RateLimit *networkRateLimit `json:"rateLimit,omitempty"`
+	Cache     *networkCache     `json:"cache,omitempty"`
+}
+
+// hasOptions checks whether any of the optional network settings are provided.
+func (a advancedNetwork) hasOptions() bool {
+	return a.Retry != nil || len(a.Endpoints) > 0 || a.Policy != "" || a.TLS != nil || len(a.Headers) > 0 || a.RateLimit != nil || a.Cache != nil
+}
+
+type networkRetry struct {
+	MaxAttempts int    `json:"maxAttempts,omitempty"`
+	MinBackoff  string `json:"minBackoff,omitempty"`
+	MaxBackoff  string `json:"maxBackoff,omitempty"`
+}
+
+// transformToConfig parses the retry backoff durations, a nil retry results in default retry configuration.
+func (r *networkRetry) transformToConfig() (config.NetworkRetry, error) {
+	if r == nil {
+		return config.NetworkRetry{}, nil
+	}
+
+	if r.MaxAttempts < 0 {
+		return config.NetworkRetry{}, fmt.Errorf("max attempts can not be negative")
+	}
+
+	retry := config.NetworkRetry{MaxAttempts: r.MaxAttempts}
+	var err error
+	if r.MinBackoff != "" {
+		retry.MinBackoff, err = time.ParseDuration(r.MinBackoff)
+		if err != nil {
+			return config.NetworkRetry{}, fmt.Errorf("invalid min backoff: %w", err)
+		}
+	}
+	if r.MaxBackoff != "" {
+		retry.MaxBackoff, err = time.ParseDuration(r.MaxBackoff)
+		if err != nil {
+			return config.NetworkRetry{}, fmt.Errorf("invalid max backoff: %w", err)
+		}
+	}
+
+	return retry, nil
+}
+
+type networkRateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	Burst             int     `json:"burst,omitempty"`
+}
+
+// transformToConfig validates the rate limit, a nil rate limit results in requests not being limited.
+func (r *networkRateLimit) transformToConfig() (config.NetworkRateLimit, error) {
+	if r == nil {
+		return config.NetworkRateLimit{}, nil
+	}
+
+	if r.RequestsPerSecond < 0 {
+		return config.NetworkRateLimit{}, fmt.Errorf("requests per second can not be negative")
+	}
+	if r.Burst < 0 {
+		return config.NetworkRateLimit{}, fmt.Errorf("burst can not be negative")
+	}
+
+	return config.NetworkRateLimit{
+		RequestsPerSecond: r.RequestsPerSecond,
+		Burst:             r.Burst,
+	}, nil
+}
+
+type networkCache struct {
+	Disk        bool  `json:"disk,omitempty"`
+	MaxDiskSize int64 `json:"maxDiskSize,omitempty"`
+}
+
+// transformToConfig validates the cache configuration, a nil cache results in data only being cached in memory.
+func (c *networkCache) transformToConfig() (config.NetworkCache, error) {
+	if c == nil {
+		return config.NetworkCache{}, nil
+	}
+
+	if c.MaxDiskSize < 0 {
+		return config.NetworkCache{}, fmt.Errorf("max disk size can not be negative")
+	}
+
+	return config.NetworkCache{
+		Disk:        c.Disk,
+		MaxDiskSize: c.MaxDiskSize,
+	}, nil
+}
+
+type networkTLS struct {
+	CAFile     string `json:"caFile,omitempty"`
+	CertFile   string `json:"certFile,omitempty"`
+	KeyFile    string `json:"keyFile,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// transformToConfig validates the TLS files, a nil TLS results in TLS not being used.
+func (t *networkTLS) transformToConfig() (*config.NetworkTLS, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, fmt.Errorf("both the client certificate and key files must be provided")
+	}
+
+	return &config.NetworkTLS{
+		CAFile:     t.CAFile,
+		CertFile:   t.CertFile,
+		KeyFile:    t.KeyFile,
+		ServerName: t.ServerName,
+	}, nil
+}
+
+// transformHeadersToConfig sorts the headers by name and replaces the values referencing environment variables.
+func transformHeadersToConfig(jsonHeaders map[string]string) ([]config.NetworkHeader, error) {
+	if len(jsonHeaders) == 0 {
+		return nil, nil
+	}
+
+	headers := make([]config.NetworkHeader, 0, len(jsonHeaders))
+	for name, value := range jsonHeaders {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header name can not be empty")
+		}
+
+		header := config.NetworkHeader{Name: name, Value: value}
+		expanded, err := expandHeaderEnv(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid header %s: %w", name, err)
+		}
+		if expanded != value {
+			header.Env = value
+			header.Value = expanded
+		}
+
+		headers = append(headers, header)
+	}
+
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+
+	return headers, nil
+}
+
+// expandHeaderEnv replaces the environment variables referenced anywhere in the header value,
+// for example "Bearer ${TOKEN}", and returns an error if any of them is not set.
+func expandHeaderEnv(value string) (string, error) {
+	var missing string
+	expanded := os.Expand(value, func(name string) string {
+		env := os.Getenv(name)
+		if env == "" && missing == "" {
+			missing = name
+		}
+		return env
+	})
+	if missing != "" {
+		return "", fmt.Errorf("required environment variable %s not set", missing)
+	}
+
+	return expanded, nil
 }
 
 func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
@@ -119,8 +424,7 @@ func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
 	var advanced advancedNetwork
 	err = json.Unmarshal(b, &advanced)
 	if err == nil {
-		j.Advanced.Host = advanced.Host
-		j.Advanced.Key = advanced.Key
+		j.Advanced = advanced
 	}
 
 	return err
@@ -20,8 +20,11 @@ package json
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 func Test_ConfigNetworkSimple(t *testing.T) {
@@ -153,3 +156,228 @@ func Test_TransformConfigAdvanced(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_ConfigNetworkRetry(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","retry":{"maxAttempts":3,"minBackoff":"100ms","maxBackoff":"5s"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	testnet, err := networks.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "access.testnet.nodes.onflow.org:9000", testnet.Host)
+	assert.Equal(t, 3, testnet.Retry.MaxAttempts)
+	assert.Equal(t, 100*time.Millisecond, testnet.Retry.MinBackoff)
+	assert.Equal(t, 5*time.Second, testnet.Retry.MaxBackoff)
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkInvalidRetry(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","retry":{"minBackoff":"soon"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.Error(t, err)
+}
+
+func Test_ConfigNetworkEndpoints(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access-001.devnet.nodes.onflow.org:9000","endpoints":["access-002.devnet.nodes.onflow.org:9000"],"policy":"round-robin"}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	testnet, err := networks.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"access-001.devnet.nodes.onflow.org:9000",
+		"access-002.devnet.nodes.onflow.org:9000",
+	}, testnet.Hosts())
+	assert.Equal(t, "round-robin", string(testnet.Policy))
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkInvalidPolicy(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","policy":"random"}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid policy random for network with name testnet, must be failover or round-robin")
+}
+
+func Test_ConfigNetworkTLS(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","tls":{"caFile":"./certs/ca.pem","certFile":"./certs/client.pem","keyFile":"./certs/client.key","serverName":"access.mainnet.nodes.onflow.org"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	assert.NoError(t, err)
+	assert.True(t, mainnet.TLS.IsEnabled())
+	assert.Equal(t, &config.NetworkTLS{
+		CAFile:     "./certs/ca.pem",
+		CertFile:   "./certs/client.pem",
+		KeyFile:    "./certs/client.key",
+		ServerName: "access.mainnet.nodes.onflow.org",
+	}, mainnet.TLS)
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkEmptyTLS(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","tls":{}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	// an empty tls object uses the system certificates
+	mainnet, err := networks.ByName("mainnet")
+	assert.NoError(t, err)
+	assert.True(t, mainnet.TLS.IsEnabled())
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkInvalidTLS(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","tls":{"certFile":"./certs/client.pem"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid tls configuration for network with name mainnet: both the client certificate and key files must be provided")
+}
+
+func Test_ConfigNetworkTLSWithKey(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","key":"5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd","tls":{"caFile":"./certs/ca.pem"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid tls configuration for network with name mainnet: can't be used together with the network key")
+}
+
+func Test_ConfigNetworkHeaders(t *testing.T) {
+	t.Setenv("ACCESS_API_KEY", "secret")
+	t.Setenv("ACCESS_TOKEN", "token")
+	b := []byte(`{"mainnet":{"host":"access.provider.io:9000","headers":{"authorization":"Bearer ${ACCESS_TOKEN}","x-api-key":"$ACCESS_API_KEY","x-client":"flow-cli"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	assert.NoError(t, err)
+	assert.Equal(t, []config.NetworkHeader{
+		{Name: "authorization", Value: "Bearer token", Env: "Bearer ${ACCESS_TOKEN}"},
+		{Name: "x-api-key", Value: "secret", Env: "$ACCESS_API_KEY"},
+		{Name: "x-client", Value: "flow-cli"},
+	}, mainnet.Headers)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token", "x-api-key": "secret", "x-client": "flow-cli"}, mainnet.HeaderValues())
+
+	// the environment variable is saved instead of its value
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkHeadersMissingEnv(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.provider.io:9000","headers":{"x-api-key":"key-$ACCESS_API_KEY_NOT_SET"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid headers for network with name mainnet: invalid header x-api-key: required environment variable ACCESS_API_KEY_NOT_SET not set")
+}
+
+func Test_ConfigNetworkRateLimit(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.devnet.nodes.onflow.org:9000","rateLimit":{"requestsPerSecond":2.5,"burst":5}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	testnet, err := networks.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, config.NetworkRateLimit{RequestsPerSecond: 2.5, Burst: 5}, testnet.RateLimit)
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkInvalidRateLimit(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.devnet.nodes.onflow.org:9000","rateLimit":{"requestsPerSecond":-1}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid rate limit for network with name testnet: requests per second can not be negative")
+}
+
+func Test_ConfigNetworkCache(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","cache":{"disk":true,"maxDiskSize":1048576}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	assert.NoError(t, err)
+	assert.Equal(t, config.NetworkCache{Disk: true, MaxDiskSize: 1048576}, mainnet.Cache)
+
+	x, _ := json.Marshal(transformNetworksToJSON(networks))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkInvalidCache(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","cache":{"disk":true,"maxDiskSize":-1}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid cache configuration for network with name mainnet: max disk size can not be negative")
+}
@@ -20,6 +20,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 )
 
 var (
@@ -52,9 +53,98 @@ type Networks []Network
 
 // Network defines the configuration for a Flow network.
 type Network struct {
-	Name string
-	Host string
-	Key  string
+	Name  string
+	Host  string
+	Key   string
+	Retry NetworkRetry
+	// Endpoints are additional access node hosts of the network, used according to the endpoint policy.
+	Endpoints []string
+	Policy    EndpointPolicy
+	// TLS is used for gRPC connections when set, otherwise the connection is insecure.
+	TLS *NetworkTLS
+	// Headers are attached to every call to the access nodes, for example to provide API keys.
+	Headers []NetworkHeader
+	// RateLimit limits the rate of requests sent to the network.
+	RateLimit NetworkRateLimit
+	// Cache defines whether chain data fetched from the network is also cached on disk.
+	Cache NetworkCache
+}
+
+// NetworkHeader is a request header, or gRPC metadata, attached to every call to the access nodes.
+type NetworkHeader struct {
+	Name  string
+	Value string
+	// Env is the original value referencing the environment variable the value was read from.
+	Env string
+}
+
+// HeaderValues returns the header values by their names.
+func (n Network) HeaderValues() map[string]string {
+	values := make(map[string]string, len(n.Headers))
+	for _, h := range n.Headers {
+		values[h.Name] = h.Value
+	}
+	return values
+}
+
+// Hosts returns all the access node hosts of the network, starting with the main host.
+func (n Network) Hosts() []string {
+	return append([]string{n.Host}, n.Endpoints...)
+}
+
+// EndpointPolicy defines how the access node is selected when the network defines multiple endpoints.
+type EndpointPolicy string
+
+const (
+	// FailoverPolicy uses the hosts in the order they are defined, switching to the next host when one is down.
+	FailoverPolicy EndpointPolicy = "failover"
+	// RoundRobinPolicy distributes the requests between all the hosts, skipping the ones that are down.
+	RoundRobinPolicy EndpointPolicy = "round-robin"
+)
+
+// NetworkRetry defines how requests to the network are retried when the access node
+// responds with a transient error.
+//
+// Zero values are replaced by the gateway defaults, setting MaxAttempts to 1 disables retrying.
+type NetworkRetry struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// NetworkRateLimit defines the rate of requests sent to the network using a token bucket,
+// which allows bursts of requests up to the burst size.
+//
+// Requests are not limited if RequestsPerSecond is zero, a zero Burst allows a single request at a time.
+type NetworkRateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// NetworkCache defines the on-disk cache of immutable chain data fetched from the network,
+// which is reused between runs. Data is always cached in memory.
+//
+// The disk cache is disabled unless Disk is set, a zero MaxDiskSize uses the gateway default size.
+type NetworkCache struct {
+	Disk bool
+	// MaxDiskSize is the maximum size of the on-disk cache in bytes, the least recently used entries are removed above it.
+	MaxDiskSize int64
+}
+
+// NetworkTLS defines the TLS configuration of gRPC connections to the access nodes.
+//
+// The server certificate is verified using the CA file if provided, otherwise using the system certificates,
+// and the client certificate and key files are used for mutual TLS.
+type NetworkTLS struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// IsEnabled checks whether the TLS configuration is provided, an empty configuration uses the system certificates.
+func (t *NetworkTLS) IsEnabled() bool {
+	return t != nil
 }
 
 // ByName get network by name or return an error if not found.
@@ -0,0 +1,474 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/pkg/errors"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// DeployOption configures how DeployProject deploys the contracts.
+type DeployOption func(*deployOptions)
+
+type deployOptions struct {
+	concurrency     int
+	ignoreLockfile  bool
+	skipUpdateCheck bool
+	atomic          bool
+}
+
+func newDeployOptions(opts []DeployOption) deployOptions {
+	options := deployOptions{concurrency: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithDeployConcurrency deploys up to concurrency contracts at the same time.
+//
+// Contracts are grouped into levels by their imports and the contracts of a level are deployed concurrently
+// after all the contracts of the previous levels are deployed. Contracts deployed to the same account are
+// proposed with different keys of the account, so the number of contracts deployed to the same account at
+// the same time is limited by the number of keys on the account that match the configured account key.
+func WithDeployConcurrency(concurrency int) DeployOption {
+	return func(options *deployOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// WithIgnoreLockfile checks every contract on the network instead of skipping contracts whose code and
+// target address match the lockfile. The lockfile is still updated with the deployed contracts.
+func WithIgnoreLockfile() DeployOption {
+	return func(options *deployOptions) {
+		options.ignoreLockfile = true
+	}
+}
+
+// WithoutUpdateCheck sends contract updates without checking them against the contract update rules first,
+// leaving the validation to the network.
+func WithoutUpdateCheck() DeployOption {
+	return func(options *deployOptions) {
+		options.skipUpdateCheck = true
+	}
+}
+
+// WithAtomicDeploy rolls back the contracts deployed before a failure, so the network isn't left half deployed.
+//
+// Contracts added by the deploy are removed and contracts updated by the deploy are updated back to the code they
+// had before, in the reverse order they were deployed. The rollback is reported by the ProjectDeploymentError.
+// Rolling back is best effort, since the rollback transactions can fail as well, for example if restoring the
+// previous code breaks the contract update rules. Transactions sent by deployment hooks are not reverted.
+//
+// Added contracts are only removed on the emulator network. Live networks restrict removing contracts and a removed
+// contract name can't be deployed again, so on other networks added contracts remain deployed and their rollback
+// is reported as failed.
+func WithAtomicDeploy() DeployOption {
+	return func(options *deployOptions) {
+		options.atomic = true
+	}
+}
+
+// projectDeploy is the state shared by the contracts deployed by one DeployProject call.
+type projectDeploy struct {
+	update  UpdateContract
+	options deployOptions
+	lock    *Lockfile
+	hooks   []deployHook
+
+	mu      sync.Mutex
+	changes []contractChange
+}
+
+// contractChange is a contract added or updated by the deploy, recorded so it can be rolled back.
+type contractChange struct {
+	account  *accounts.Account
+	contract *project.Contract
+	name     string
+	// existed is set if the contract was updated from the previous code, otherwise it was added
+	existed  bool
+	previous []byte
+	// previousEntry is the lockfile entry of the contract before it was deployed, if wasLocked is set
+	previousEntry LockedContract
+	wasLocked     bool
+}
+
+func (d *projectDeploy) record(change contractChange) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.changes = append(d.changes, change)
+}
+
+// errContractRemovalRestricted is reported for added contracts that are not removed by a rollback on live networks.
+var errContractRemovalRestricted = errors.New("contracts are only removed on the emulator network, since live networks restrict removing contracts and a removed contract name can't be deployed again")
+
+// ContractRollback describes how a contract deployed before a failure was rolled back.
+type ContractRollback struct {
+	Contract *project.Contract
+	// Removed is set for contracts that were added by the deploy and removed, otherwise the previous code was restored.
+	Removed bool
+	ID      flow.Identifier
+	// Error is set if rolling back the contract failed and the contract remains deployed.
+	Error error
+}
+
+// rollbackDeploy rolls back the contracts changed by the deploy in the reverse order they were deployed.
+//
+// The rollbacks are returned and not logged, so the caller reports them.
+func (f *Flowkit) rollbackDeploy(ctx context.Context, run *projectDeploy) []ContractRollback {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	if len(run.changes) == 0 {
+		return nil
+	}
+
+	f.logger.Info(fmt.Sprintf("\n%s Rolling back %d deployed contracts\n", output.WarningEmoji(), len(run.changes)))
+	defer f.logger.StopProgress()
+
+	rollbacks := make([]ContractRollback, 0, len(run.changes))
+	for i := len(run.changes) - 1; i >= 0; i-- {
+		change := run.changes[i]
+		rollback := ContractRollback{Contract: change.contract, Removed: !change.existed}
+
+		var tx *transactions.Transaction
+		var err error
+		if change.existed {
+			f.logger.StartProgress(fmt.Sprintf("Restoring contract '%s' on the account '%s'...", change.name, change.account.Address))
+			tx, err = transactions.NewUpdateAccountContract(change.account, change.name, change.previous)
+		} else if f.network.Name != config.EmulatorNetwork.Name {
+			err = errContractRemovalRestricted
+		} else {
+			f.logger.StartProgress(fmt.Sprintf("Removing contract '%s' from the account '%s'...", change.name, change.account.Address))
+			tx, err = transactions.NewRemoveAccountContract(change.account, change.name)
+		}
+		if err == nil {
+			rollback.ID, err = f.sendAccountTransaction(ctx, change.account, tx)
+		}
+		rollback.Error = err
+
+		if err == nil {
+			if change.wasLocked {
+				run.lock.SetContract(f.network.Name, change.contract.Name, change.previousEntry)
+			} else {
+				run.lock.RemoveContract(f.network.Name, change.contract.Name)
+			}
+		}
+
+		rollbacks = append(rollbacks, rollback)
+	}
+
+	return rollbacks
+}
+
+// sendAccountTransaction signs and sends the transaction with the account and waits for it to be executed.
+func (f *Flowkit) sendAccountTransaction(
+	ctx context.Context,
+	account *accounts.Account,
+	tx *transactions.Transaction,
+) (flow.Identifier, error) {
+	tx, err := f.prepareTransaction(ctx, tx, account)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	return f.sendExecutedTransaction(ctx, tx)
+}
+
+// sendExecutedTransaction sends the signed transaction and waits for it to be executed, returning the execution error.
+func (f *Flowkit) sendExecutedTransaction(ctx context.Context, tx *transactions.Transaction) (flow.Identifier, error) {
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	result, err := f.waitForExecutedResult(ctx, sentTx.ID())
+	if err != nil {
+		return sentTx.ID(), err
+	}
+	if result.Error != nil {
+		return sentTx.ID(), result.Error
+	}
+
+	return sentTx.ID(), nil
+}
+
+// newLockedContract returns the lockfile entry for deploying the resolved contract program to the contract account.
+func newLockedContract(contract *project.Contract, program *project.Program) LockedContract {
+	imports := make(map[string]string)
+	for name, address := range program.ContractImports() {
+		imports[name] = "0x" + address.Hex()
+	}
+
+	return LockedContract{
+		Account:    contract.AccountName,
+		Address:    "0x" + contract.AccountAddress.Hex(),
+		SourceHash: HashCode(contract.Code()),
+		CodeHash:   HashCode(program.Code()),
+		Imports:    imports,
+	}
+}
+
+// lockedContract returns the lockfile entry of the contract and whether it recorded deploying the same code
+// to the same address as the entry.
+func lockedContract(lock *Lockfile, network string, name string, entry LockedContract) (LockedContract, bool) {
+	locked, ok := lock.Contract(network, name)
+	return locked, ok && locked.Address == entry.Address && locked.CodeHash == entry.CodeHash
+}
+
+// verifyLockfile removes the lockfile entries of the network unless the block of the latest deploy recorded in
+// the lockfile is still part of the network, which isn't the case after the emulator is restarted or a network reset.
+func (f *Flowkit) verifyLockfile(ctx context.Context, lock *Lockfile) {
+	contracts := lock.Contracts(f.network.Name)
+	if len(contracts) == 0 {
+		return
+	}
+
+	var latest LockedContract
+	for _, contract := range contracts {
+		if contract.BlockHeight >= latest.BlockHeight {
+			latest = contract
+		}
+	}
+
+	if latest.BlockID != "" {
+		block, err := f.gateway.GetBlockByHeight(ctx, latest.BlockHeight)
+		if err == nil && block.ID.String() == latest.BlockID {
+			return
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf(
+		"%s Deploys recorded in the lockfile were not found on network %s, all contracts will be checked",
+		output.WarningEmoji(),
+		f.network.Name,
+	))
+	lock.ResetNetwork(f.network.Name)
+}
+
+// removeLockedContract removes a contract removed from the account from the lockfile, so deploying the project adds it again.
+func (f *Flowkit) removeLockedContract(account *accounts.Account, name string) error {
+	state, err := f.State()
+	if err != nil {
+		return nil // the lockfile is only used with a project state
+	}
+
+	lock, err := state.Lockfile()
+	if err != nil {
+		return err
+	}
+
+	locked, ok := lock.Contract(f.network.Name, name)
+	if !ok || locked.Address != "0x"+account.Address.Hex() {
+		return nil
+	}
+
+	lock.RemoveContract(f.network.Name, name)
+	return state.SaveLockfile(lock)
+}
+
+// errDependencyFailed is reported for contracts that were not deployed because a contract they import,
+// or a deployment hook of that contract, failed.
+var errDependencyFailed = errors.New("not deployed because a contract it imports or its deployment hooks failed")
+
+// deployLevels deploys the contracts level by level, deploying the contracts of each level concurrently.
+//
+// Contracts importing a contract that failed, or whose hooks failed, are skipped, and the other contracts are still
+// deployed unless the deploy is atomic, in which case deploying stops after the level in which anything failed.
+func (f *Flowkit) deployLevels(
+	ctx context.Context,
+	state *State,
+	deployment *project.Deployment,
+	run *projectDeploy,
+) ([]*project.Contract, error) {
+	levels, err := deployment.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	var deployed []*project.Contract
+	for _, level := range levels {
+		deployed = append(deployed, level...)
+	}
+
+	f.logger.Info(fmt.Sprintf(
+		"\nDeploying %d contracts in %d levels for accounts: %s\n",
+		len(deployed),
+		len(levels),
+		state.AccountsForNetwork(f.network).String(),
+	))
+	defer f.logger.StopProgress()
+
+	pools := &keyPools{gateway: f.gateway, pools: make(map[flow.Address]*KeyPool)}
+	deployErr := &ProjectDeploymentError{}
+	// contracts that failed or were skipped, so the contracts importing them are skipped as well
+	failed := make(map[string]bool)
+
+	for _, level := range levels {
+		// contracts deployed so far are rolled back, so there is no point in deploying the rest
+		if deployErr.failed() && run.options.atomic {
+			break
+		}
+
+		pending := make([]*project.Contract, 0, len(level))
+		for _, contract := range level {
+			if dependsOnFailed(deployment.Dependencies(contract.Name), failed) {
+				failed[contract.Name] = true
+				deployErr.add(contract, errDependencyFailed, fmt.Sprintf("skipped deploying contract %s", contract.Name))
+				continue
+			}
+
+			// hooks running before the contracts run one by one before the level is deployed
+			hooks, err := f.beforeContractHooks(ctx, state, contract, run)
+			if err != nil {
+				failed[contract.Name] = true
+				deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
+				continue
+			}
+			if !f.runDeployHooks(ctx, state, hooks, deployErr) {
+				failed[contract.Name] = true
+				continue
+			}
+
+			pending = append(pending, contract)
+		}
+		if deployErr.failed() && run.options.atomic {
+			break
+		}
+
+		targetAccounts := make([]*accounts.Account, len(pending))
+		for j, contract := range pending {
+			targetAccounts[j], err = state.Accounts().ByName(contract.AccountName)
+			if err != nil {
+				return nil, fmt.Errorf("target account for deploying contract not found in configuration")
+			}
+		}
+
+		errs := make([]error, len(pending))
+		sem := make(chan struct{}, run.options.concurrency)
+		var wg sync.WaitGroup
+		for j, contract := range pending {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[j] = ctx.Err()
+				continue
+			}
+
+			wg.Add(1)
+			go func(j int, contract *project.Contract) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				errs[j] = f.deployPooledContract(ctx, pools, targetAccounts[j], contract, run)
+			}(j, contract)
+		}
+		wg.Wait()
+
+		for j, err := range errs {
+			if err != nil {
+				failed[pending[j].Name] = true
+				deployErr.add(pending[j], err, fmt.Sprintf("failed to deploy contract %s", pending[j].Name))
+			}
+		}
+
+		// hooks run one by one once the level is deployed, so the next level can depend on them
+		for j, contract := range pending {
+			if errs[j] != nil || f.runDeployHooks(ctx, state, run.contractHooks(contract), deployErr) {
+				continue
+			}
+
+			failed[contract.Name] = true
+			if run.options.atomic {
+				break
+			}
+		}
+	}
+
+	if deployErr.failed() {
+		return nil, deployErr
+	}
+
+	f.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
+	return deployed, nil
+}
+
+// dependsOnFailed checks whether any of the dependencies failed to deploy.
+func dependsOnFailed(dependencies []string, failed map[string]bool) bool {
+	for _, name := range dependencies {
+		if failed[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// deployPooledContract deploys the contract proposing the transaction with a key from the target account key pool.
+func (f *Flowkit) deployPooledContract(
+	ctx context.Context,
+	pools *keyPools,
+	targetAccount *accounts.Account,
+	contract *project.Contract,
+	run *projectDeploy,
+) error {
+	// contracts skipped because they match the lockfile don't need a key from the pool
+	if !run.options.ignoreLockfile {
+		state, err := f.State()
+		if err != nil {
+			return err
+		}
+
+		script := Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()}
+		program, _, err := f.resolveContract(state, script)
+		if err != nil {
+			return err
+		}
+
+		entry := newLockedContract(contract, program)
+		if _, unchanged := lockedContract(run.lock, f.network.Name, contract.Name, entry); unchanged {
+			return f.deployContract(ctx, targetAccount, contract, run)
+		}
+	}
+
+	pool, err := pools.get(ctx, targetAccount)
+	if err != nil {
+		return err
+	}
+
+	key, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the sequence number of the key is fetched when the transaction is prepared, so the pool only guarantees
+	// that the key isn't used by another contract deployed at the same time
+	err = f.deployContract(ctx, pool.Account(key), contract, run)
+	pool.Release(key, err == nil)
+
+	return err
+}
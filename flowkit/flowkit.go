@@ -23,9 +23,11 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	goeth "github.com/ethereum/go-ethereum/accounts"
 	"github.com/lmars/go-slip10"
@@ -94,6 +96,69 @@ type EventWorker struct {
 	BlocksPerWorker uint64
 }
 
+// validate checks the worker starts at least one worker fetching at least one block.
+func (w *EventWorker) validate() error {
+	if w.Count < 1 {
+		return fmt.Errorf("event worker count must be at least 1, got %d", w.Count)
+	}
+	if w.BlocksPerWorker < 1 {
+		return fmt.Errorf("event worker blocks per worker must be at least 1, got %d", w.BlocksPerWorker)
+	}
+	return nil
+}
+
+// defaultEventWorker is used when fetching events without providing a worker.
+var defaultEventWorker = EventWorker{
+	Count:           1,
+	BlocksPerWorker: 250,
+}
+
+// eventsPollInterval is the interval between checking for new sealed blocks when subscribed to events.
+const eventsPollInterval = time.Second
+
+// WaitStrategy defines how to wait for the result of a sent transaction.
+//
+// The transaction result is polled every poll interval until the transaction reaches the status,
+// or the timeout passes. Zero values use the DefaultWaitStrategy values and no timeout.
+type WaitStrategy struct {
+	Status       flow.TransactionStatus
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// DefaultWaitStrategy waits for the transaction to be sealed.
+var DefaultWaitStrategy = WaitStrategy{
+	Status:       flow.TransactionStatusSealed,
+	PollInterval: gateway.DefaultPollInterval,
+}
+
+// waitStatuses are the transaction statuses that can be waited for.
+var waitStatuses = map[string]flow.TransactionStatus{
+	"finalized": flow.TransactionStatusFinalized,
+	"executed":  flow.TransactionStatusExecuted,
+	"sealed":    flow.TransactionStatusSealed,
+}
+
+// NewWaitStrategy creates a wait strategy for the status using the default poll interval and no timeout.
+//
+// Valid status values are "finalized", "executed" and "sealed".
+func NewWaitStrategy(status string) (WaitStrategy, error) {
+	s, ok := waitStatuses[strings.ToLower(status)]
+	if !ok {
+		return WaitStrategy{}, fmt.Errorf("invalid transaction status: %s, valid are: \"finalized\", \"executed\" or \"sealed\"", status)
+	}
+
+	return WaitStrategy{Status: s, PollInterval: DefaultWaitStrategy.PollInterval}, nil
+}
+
+// statusName returns the waited status in the form used by progress messages.
+func (w WaitStrategy) statusName() string {
+	if w.Status == flow.TransactionStatusUnknown {
+		return strings.ToLower(DefaultWaitStrategy.Status.String())
+	}
+	return strings.ToLower(w.Status.String())
+}
+
 var _ Services = &Flowkit{}
 
 func NewFlowkit(
@@ -102,7 +167,13 @@ func NewFlowkit(
 	gateway gateway.Gateway,
 	logger output.Logger,
 ) *Flowkit {
-	return &Flowkit{state, network, gateway, logger}
+	return &Flowkit{
+		state:   state,
+		network: network,
+		gateway: gateway,
+		logger:  logger,
+		wait:    DefaultWaitStrategy,
+	}
 }
 
 type Flowkit struct {
@@ -110,6 +181,8 @@ type Flowkit struct {
 	network config.Network
 	gateway gateway.Gateway
 	logger  output.Logger
+	waitMu  sync.RWMutex
+	wait    WaitStrategy
 }
 
 func (f *Flowkit) Network() config.Network {
@@ -124,6 +197,68 @@ func (f *Flowkit) SetLogger(logger output.Logger) {
 	f.logger = logger
 }
 
+func (f *Flowkit) SetWaitStrategy(strategy WaitStrategy) {
+	f.waitMu.Lock()
+	defer f.waitMu.Unlock()
+	f.wait = strategy
+}
+
+// waitStrategy returns the current wait strategy, it can be changed while transactions are being sent.
+func (f *Flowkit) waitStrategy() WaitStrategy {
+	f.waitMu.RLock()
+	defer f.waitMu.RUnlock()
+	return f.wait
+}
+
+// waitForTransactionResult waits for the sent transaction result according to the wait strategy.
+func (f *Flowkit) waitForTransactionResult(ctx context.Context, ID flow.Identifier) (*flow.TransactionResult, error) {
+	f.logger.StartProgress(fmt.Sprintf("Waiting for transaction to be %s...", f.waitStrategy().statusName()))
+	defer f.logger.StopProgress()
+
+	return f.waitForResult(ctx, ID)
+}
+
+// waitForResult waits for the transaction result using the wait strategy without reporting progress.
+func (f *Flowkit) waitForResult(ctx context.Context, ID flow.Identifier) (*flow.TransactionResult, error) {
+	return f.waitForStatus(ctx, ID, flow.TransactionStatusUnknown)
+}
+
+// waitForExecutedResult waits for the transaction result using the wait strategy, but at least until the transaction
+// is executed, since the result error and events are only known once the transaction is executed.
+func (f *Flowkit) waitForExecutedResult(ctx context.Context, ID flow.Identifier) (*flow.TransactionResult, error) {
+	return f.waitForStatus(ctx, ID, flow.TransactionStatusExecuted)
+}
+
+// waitForStatus waits for the transaction result using the wait strategy, or the minimum status if it comes later.
+func (f *Flowkit) waitForStatus(
+	ctx context.Context,
+	ID flow.Identifier,
+	minimum flow.TransactionStatus,
+) (*flow.TransactionResult, error) {
+	wait := f.waitStrategy()
+	if wait.Status == flow.TransactionStatusUnknown {
+		wait.Status = DefaultWaitStrategy.Status
+	}
+	if wait.Status < minimum {
+		wait.Status = minimum
+	}
+	status := wait.Status
+
+	waitCtx := ctx
+	if wait.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, wait.Timeout)
+		defer cancel()
+	}
+
+	result, err := gateway.WaitForTransactionResult(waitCtx, f.gateway, ID, status, wait.PollInterval)
+	if err != nil && ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("transaction %s was not %s within %s", ID, wait.statusName(), wait.Timeout)
+	}
+
+	return result, err
+}
+
 func (f *Flowkit) State() (*State, error) {
 	if f.state == nil {
 		return nil, config.ErrDoesNotExist
@@ -136,8 +271,13 @@ func (f *Flowkit) Ping() error {
 }
 
 // GetAccount fetches account on the Flow network.
-func (f *Flowkit) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
-	return f.gateway.GetAccount(address)
+func (f *Flowkit) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return f.gateway.GetAccount(ctx, address)
+}
+
+// GetAccountAtBlockHeight fetches account on the Flow network as it was at the block height.
+func (f *Flowkit) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return f.gateway.GetAccountAtBlockHeight(ctx, address, height)
 }
 
 // CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
@@ -145,7 +285,7 @@ func (f *Flowkit) GetAccount(_ context.Context, address flow.Address) (*flow.Acc
 //
 // Keys is a slice but only one can be passed as well. If the transaction fails or there are other issues an error is returned.
 func (f *Flowkit) CreateAccount(
-	_ context.Context,
+	ctx context.Context,
 	signer *accounts.Account,
 	keys []accounts.PublicKey,
 ) (*flow.Account, flow.Identifier, error) {
@@ -175,7 +315,7 @@ func (f *Flowkit) CreateAccount(
 		return nil, flow.EmptyID, err
 	}
 
-	tx, err = f.prepareTransaction(tx, signer)
+	tx, err = f.prepareTransaction(ctx, tx, signer)
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -184,15 +324,13 @@ func (f *Flowkit) CreateAccount(
 	f.logger.StartProgress("Creating account...")
 	defer f.logger.StopProgress()
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return nil, flow.EmptyID, errors.Wrap(err, "account creation transaction failed")
 	}
 
-	f.logger.StartProgress("Waiting for transaction to be sealed...")
-	defer f.logger.StopProgress()
-
-	result, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	// the created address is read from the events, which are only available once the transaction is executed
+	result, err := f.waitForExecutedResult(ctx, sentTx.ID())
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -207,7 +345,7 @@ func (f *Flowkit) CreateAccount(
 		return nil, flow.EmptyID, fmt.Errorf("new account address couldn't be fetched")
 	}
 
-	account, err := f.gateway.GetAccount(*newAccountAddress[0]) // we know it's the only and first event
+	account, err := f.gateway.GetAccount(ctx, *newAccountAddress[0]) // we know it's the only and first event
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -217,16 +355,17 @@ func (f *Flowkit) CreateAccount(
 
 // prepareTransaction prepares transaction for sending with data from network
 func (f *Flowkit) prepareTransaction(
+	ctx context.Context,
 	tx *transactions.Transaction,
 	account *accounts.Account,
 ) (*transactions.Transaction, error) {
 
-	block, err := f.gateway.GetLatestBlock()
+	block, err := f.gateway.GetLatestBlock(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	proposer, err := f.gateway.GetAccount(account.Address)
+	proposer, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -254,31 +393,18 @@ func UpdateExistingContract(updateExisting bool) UpdateContract {
 	}
 }
 
-// AddContract to the Flow account provided and return the transaction ID.
-//
-// If the contract already exists on the account the operation will fail and error will be returned.
-// Use UpdateExistingContract(bool) to define whether a contract should be updated or not, or you can also
-// define a custom UpdateContract function which returns bool indicating whether a contract should be updated or not.
-func (f *Flowkit) AddContract(
-	ctx context.Context,
-	account *accounts.Account,
-	contract Script,
-	update UpdateContract,
-) (flow.Identifier, bool, error) {
-	state, err := f.State()
-	if err != nil {
-		return flow.EmptyID, false, err
-	}
-
+// resolveContract replaces the imports in the contract code with the addresses the imported contracts are deployed
+// to on the network and returns the resolved program together with the contract name.
+func (f *Flowkit) resolveContract(state *State, contract Script) (*project.Program, string, error) {
 	program, err := project.NewProgram(contract.Code, contract.Args, contract.Location)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return nil, "", err
 	}
 
 	if program.HasImports() {
 		contracts, err := state.DeploymentContractsByNetwork(f.network)
 		if err != nil {
-			return flow.EmptyID, false, err
+			return nil, "", err
 		}
 
 		importReplacer := project.NewImportReplacer(
@@ -288,15 +414,69 @@ func (f *Flowkit) AddContract(
 
 		program, err = importReplacer.Replace(program)
 		if err != nil {
-			return flow.EmptyID, false, err
+			return nil, "", err
 		}
 	}
 
 	name, err := program.Name()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return program, name, nil
+}
+
+// AddContract to the Flow account provided and return the transaction ID.
+//
+// If the contract already exists on the account the operation will fail and error will be returned.
+// Use UpdateExistingContract(bool) to define whether a contract should be updated or not, or you can also
+// define a custom UpdateContract function which returns bool indicating whether a contract should be updated or not.
+//
+// Updates are checked against the contract update rules before they are sent, and an InvalidContractUpdateError
+// is returned listing the violations if the network would reject the update.
+func (f *Flowkit) AddContract(
+	ctx context.Context,
+	account *accounts.Account,
+	contract Script,
+	update UpdateContract,
+) (flow.Identifier, bool, error) {
+	state, err := f.State()
+	if err != nil {
+		return flow.EmptyID, false, err
+	}
+
+	program, name, err := f.resolveContract(state, contract)
 	if err != nil {
 		return flow.EmptyID, false, err
 	}
 
+	result, err := f.addContract(ctx, state, account, contract, program, name, update, true)
+	return result.txID, result.updated, err
+}
+
+// addedContract is the result of adding or updating a contract on an account.
+type addedContract struct {
+	txID    flow.Identifier
+	updated bool
+	result  *flow.TransactionResult
+	// existed is set if the contract existed on the account and was updated from the previous code
+	existed  bool
+	previous []byte
+}
+
+// addContract adds the resolved contract program to the account, or updates it if it exists and the update function allows it.
+//
+// Updates are checked against the contract update rules before they are sent if checkUpdate is set.
+func (f *Flowkit) addContract(
+	ctx context.Context,
+	state *State,
+	account *accounts.Account,
+	contract Script,
+	program *project.Program,
+	name string,
+	update UpdateContract,
+	checkUpdate bool,
+) (addedContract, error) {
 	tx, err := transactions.NewAddAccountContract(
 		account,
 		name,
@@ -304,44 +484,51 @@ func (f *Flowkit) AddContract(
 		contract.Args,
 	)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return addedContract{}, err
 	}
 
 	f.logger.StartProgress(fmt.Sprintf("Checking contract '%s' on account '%s'...", name, account.Address))
 
 	// check if contract exists on account
-	flowAccount, err := f.gateway.GetAccount(account.Address)
+	flowAccount, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return addedContract{}, err
 	}
 	existingContract, exists := flowAccount.Contracts[name]
 	noDiffInContract := bytes.Equal(program.Code(), existingContract)
 
 	if exists && noDiffInContract {
-		return flow.EmptyID, false, errUpdateNoDiff
+		return addedContract{}, errUpdateNoDiff
 	}
 
 	updateExisting := update(existingContract, program.Code())
 	if exists && !updateExisting {
-		return flow.EmptyID, false, fmt.Errorf(fmt.Sprintf("contract %s exists in account %s", name, account.Name))
+		return addedContract{}, fmt.Errorf(fmt.Sprintf("contract %s exists in account %s", name, account.Name))
 	}
 
 	if exists && updateExisting {
+		if checkUpdate {
+			err = f.checkContractUpdate(account.Address, name, existingContract, program)
+			if err != nil {
+				return addedContract{}, err
+			}
+		}
+
 		tx, err = transactions.NewUpdateAccountContract(account, name, program.Code())
 		if err != nil {
-			return flow.EmptyID, false, err
+			return addedContract{}, err
 		}
 	}
 
-	tx, err = f.prepareTransaction(tx, account)
+	tx, err = f.prepareTransaction(ctx, tx, account)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return addedContract{}, err
 	}
 
 	// send transaction with contract
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
-		return tx.FlowTransaction().ID(), false, fmt.Errorf("failed to send transaction to deploy a contract: %w", err)
+		return addedContract{txID: tx.FlowTransaction().ID()}, fmt.Errorf("failed to send transaction to deploy a contract: %w", err)
 	}
 
 	if exists {
@@ -350,13 +537,12 @@ func (f *Flowkit) AddContract(
 		f.logger.StartProgress(fmt.Sprintf("Contract '%s' deploying on the account '%s'.", name, account.Address))
 	}
 
-	// we wait for transaction to be sealed
-	trx, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	trx, err := f.waitForExecutedResult(ctx, sentTx.ID())
 	if err != nil {
-		return tx.FlowTransaction().ID(), false, err
+		return addedContract{txID: tx.FlowTransaction().ID()}, err
 	}
 	if trx.Error != nil {
-		return tx.FlowTransaction().ID(), false, trx.Error
+		return addedContract{txID: tx.FlowTransaction().ID()}, trx.Error
 	}
 
 	d := state.Deployments().ByAccountAndNetwork(account.Name, f.network.Name)
@@ -374,19 +560,25 @@ func (f *Flowkit) AddContract(
 		})
 	}
 
-	return sentTx.ID(), updateExisting, err
+	return addedContract{
+		txID:     sentTx.ID(),
+		updated:  updateExisting,
+		result:   trx,
+		existed:  exists,
+		previous: existingContract,
+	}, err
 }
 
 // RemoveContract from the provided account by its name.
 //
 // If removal is successful transaction ID is returned.
 func (f *Flowkit) RemoveContract(
-	_ context.Context,
+	ctx context.Context,
 	account *accounts.Account,
 	contractName string,
 ) (flow.Identifier, error) {
 	// check if contracts exists on the account
-	flowAcc, err := f.gateway.GetAccount(account.Address)
+	flowAcc, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -405,7 +597,7 @@ func (f *Flowkit) RemoveContract(
 		return flow.EmptyID, err
 	}
 
-	tx, err = f.prepareTransaction(tx, account)
+	tx, err = f.prepareTransaction(ctx, tx, account)
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -415,12 +607,12 @@ func (f *Flowkit) RemoveContract(
 	)
 	defer f.logger.StopProgress()
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return flow.EmptyID, err
 	}
 
-	txr, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	txr, err := f.waitForExecutedResult(ctx, sentTx.ID())
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -430,19 +622,24 @@ func (f *Flowkit) RemoveContract(
 
 	f.logger.StopProgress()
 
+	err = f.removeLockedContract(account, contractName)
+	if err != nil {
+		return sentTx.ID(), err
+	}
+
 	return sentTx.ID(), nil
 }
 
 // GetBlock by the query from Flow blockchain. Query can define a block by ID, block by height or require the latest block.
-func (f *Flowkit) GetBlock(_ context.Context, query BlockQuery) (*flow.Block, error) {
+func (f *Flowkit) GetBlock(ctx context.Context, query BlockQuery) (*flow.Block, error) {
 	var err error
 	var block *flow.Block
 	if query.Latest {
-		block, err = f.gateway.GetLatestBlock()
+		block, err = f.gateway.GetLatestBlock(ctx)
 	} else if query.ID != nil {
-		block, err = f.gateway.GetBlockByID(*query.ID)
+		block, err = f.gateway.GetBlockByID(ctx, *query.ID)
 	} else {
-		block, err = f.gateway.GetBlockByHeight(query.Height)
+		block, err = f.gateway.GetBlockByHeight(ctx, query.Height)
 	}
 
 	if err != nil {
@@ -457,8 +654,8 @@ func (f *Flowkit) GetBlock(_ context.Context, query BlockQuery) (*flow.Block, er
 }
 
 // GetCollection by the ID from Flow network.
-func (f *Flowkit) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Collection, error) {
-	return f.gateway.GetCollection(ID)
+func (f *Flowkit) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return f.gateway.GetCollection(ctx, ID)
 }
 
 // GetEvents from Flow network by their event name in the specified height interval defined by start and end inclusive.
@@ -468,7 +665,7 @@ func (f *Flowkit) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Co
 // Providing worker value will produce faster response as the interval will be scanned concurrently. This parameter is optional,
 // if not provided only a single worker will be used.
 func (f *Flowkit) GetEvents(
-	_ context.Context,
+	ctx context.Context,
 	names []string,
 	startHeight uint64,
 	endHeight uint64,
@@ -479,14 +676,18 @@ func (f *Flowkit) GetEvents(
 	}
 
 	if worker == nil { // if no worker is passed, create a default one
-		worker = &EventWorker{
-			Count:           1,
-			BlocksPerWorker: 250,
-		}
+		worker = &defaultEventWorker
+	}
+	if err := worker.validate(); err != nil {
+		return nil, err
 	}
 
 	queries := makeEventQueries(names, startHeight, endHeight, worker.BlocksPerWorker)
 
+	// cancelled when returning, so the workers and the query feeder stop if fetching fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	jobChan := make(chan grpc.EventRangeQuery, worker.Count)
 	results := make(chan eventWorkerResult)
 
@@ -496,7 +697,7 @@ func (f *Flowkit) GetEvents(
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			f.eventWorker(jobChan, results)
+			f.eventWorker(ctx, jobChan, results)
 		}()
 	}
 
@@ -510,7 +711,11 @@ func (f *Flowkit) GetEvents(
 	go func() {
 		defer close(jobChan)
 		for _, query := range queries {
-			jobChan <- query
+			select {
+			case jobChan <- query:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
@@ -523,16 +728,25 @@ func (f *Flowkit) GetEvents(
 		resultEvents = append(resultEvents, eventResult.events...)
 	}
 
+	// workers stop without a result when the context is cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	return resultEvents, nil
 }
 
-func (f *Flowkit) eventWorker(jobChan <-chan grpc.EventRangeQuery, results chan<- eventWorkerResult) {
+func (f *Flowkit) eventWorker(ctx context.Context, jobChan <-chan grpc.EventRangeQuery, results chan<- eventWorkerResult) {
 	for q := range jobChan {
-		blockEvents, err := f.gateway.GetEvents(q.Type, q.StartHeight, q.EndHeight)
-		if err != nil {
-			results <- eventWorkerResult{nil, err}
+		// a single result is sent for each query, either the events or the error
+		result := eventWorkerResult{}
+		result.events, result.err = f.gateway.GetEvents(ctx, q.Type, q.StartHeight, q.EndHeight)
+
+		select {
+		case results <- result:
+		case <-ctx.Done():
+			return
 		}
-		results <- eventWorkerResult{blockEvents, nil}
 	}
 }
 
@@ -541,6 +755,136 @@ type eventWorkerResult struct {
 	err    error
 }
 
+// SubscribeEvents from Flow network by their event names, starting at the start height and following new sealed blocks.
+//
+// If the start height is zero only events from blocks sealed after subscribing are delivered. New blocks are polled
+// and their events fetched using the optional worker the same way as in GetEvents. Events are delivered in block order,
+// with all the events of a block in a single value, and blocks without events are skipped.
+//
+// The subscription runs until the context is cancelled or fetching fails, in which case the error is sent on the
+// error channel. Both channels are closed when the subscription ends.
+func (f *Flowkit) SubscribeEvents(
+	ctx context.Context,
+	names []string,
+	startHeight uint64,
+	worker *EventWorker,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("at least one event name must be provided")
+	}
+
+	if worker == nil {
+		worker = &defaultEventWorker
+	}
+	if err := worker.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := f.gateway.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if startHeight == 0 {
+		startHeight = latest.Height + 1
+	}
+
+	events := make(chan flow.BlockEvents)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		// limit each fetch to the blocks the workers fetch at once, so events are delivered while catching up
+		maxBlocks := uint64(worker.Count) * worker.BlocksPerWorker
+		next := startHeight
+
+		for {
+			for next <= latest.Height {
+				end := latest.Height
+				if end-next >= maxBlocks {
+					end = next + maxBlocks - 1
+				}
+
+				blockEvents, err := f.GetEvents(ctx, names, next, end, worker)
+				if err != nil {
+					if ctx.Err() == nil {
+						errs <- err
+					}
+					return
+				}
+
+				for _, e := range mergeBlockEvents(blockEvents) {
+					select {
+					case events <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				next = end + 1
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(eventsPollInterval):
+			}
+
+			latest, err = f.gateway.GetLatestBlock(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// mergeBlockEvents merges events of different types for the same block and sorts them in the order they were emitted.
+//
+// Blocks without any events are skipped and the blocks are returned ordered by height.
+func mergeBlockEvents(blockEvents []flow.BlockEvents) []flow.BlockEvents {
+	blocks := make(map[uint64]*flow.BlockEvents)
+	for _, b := range blockEvents {
+		if len(b.Events) == 0 {
+			continue
+		}
+
+		block, ok := blocks[b.Height]
+		if !ok {
+			block = &flow.BlockEvents{
+				BlockID:        b.BlockID,
+				Height:         b.Height,
+				BlockTimestamp: b.BlockTimestamp,
+			}
+			blocks[b.Height] = block
+		}
+		block.Events = append(block.Events, b.Events...)
+	}
+
+	merged := make([]flow.BlockEvents, 0, len(blocks))
+	for _, block := range blocks {
+		sort.SliceStable(block.Events, func(i, j int) bool {
+			if block.Events[i].TransactionIndex != block.Events[j].TransactionIndex {
+				return block.Events[i].TransactionIndex < block.Events[j].TransactionIndex
+			}
+			return block.Events[i].EventIndex < block.Events[j].EventIndex
+		})
+		merged = append(merged, *block)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Height < merged[j].Height
+	})
+
+	return merged
+}
+
 func makeEventQueries(
 	events []string,
 	startHeight uint64,
@@ -687,7 +1031,28 @@ func (f *Flowkit) derivePrivateKeyFromSeed(
 // Retrieve all the contracts for specified network, sort them for deployment deploy one by one and replace
 // the imports in the contract source, so it corresponds to the account name the contract was deployed to.
 // If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
-func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*project.Contract, error) {
+//
+// Options can be provided to deploy independent contracts concurrently using WithDeployConcurrency,
+// and to skip checking contract updates against the update rules using WithoutUpdateCheck.
+//
+// Deployed contracts are recorded per network in the project lockfile, and contracts unchanged since they were
+// last deployed are skipped without fetching the target account, unless WithIgnoreLockfile is provided.
+//
+// Hooks of the deployments run their transactions before or after the contract they refer to is added or updated,
+// and hooks that don't refer to a contract run before or after all contracts are deployed if any contract of their
+// account changes. A failed hook running before a contract is reported the same way as a failed contract.
+//
+// With WithAtomicDeploy the contracts deployed before a failure are rolled back, see WithAtomicDeploy.
+func (f *Flowkit) DeployProject(
+	ctx context.Context,
+	update UpdateContract,
+	opts ...DeployOption,
+) ([]*project.Contract, error) {
+	options := newDeployOptions(opts)
+	if options.concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency %d, must be at least 1", options.concurrency)
+	}
+
 	state, err := f.State()
 	if err != nil {
 		return nil, err
@@ -703,6 +1068,74 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 		return nil, err
 	}
 
+	lock, err := state.Lockfile()
+	if err != nil {
+		return nil, err
+	}
+	if !options.ignoreLockfile {
+		f.verifyLockfile(ctx, lock)
+	}
+
+	run := &projectDeploy{
+		update:  update,
+		options: options,
+		lock:    lock,
+		hooks:   newDeployHooks(state.Deployments().ByNetwork(f.network.Name)),
+	}
+
+	beforeHooks, err := f.beforeDeploymentHooks(ctx, state, contracts, run)
+	if err != nil {
+		return nil, err
+	}
+	deployErr := &ProjectDeploymentError{}
+	if !f.runDeployHooks(ctx, state, beforeHooks, deployErr) {
+		return nil, deployErr
+	}
+
+	var deployed []*project.Contract
+	if options.concurrency > 1 {
+		deployed, err = f.deployLevels(ctx, state, deployment, run)
+	} else {
+		deployed, err = f.deploySorted(ctx, state, deployment, run)
+	}
+
+	if err == nil {
+		deployErr := &ProjectDeploymentError{}
+		if !f.runDeployHooks(ctx, state, run.deploymentHooks(), deployErr) {
+			err = deployErr
+		}
+	}
+
+	if err != nil && options.atomic {
+		rollbacks := f.rollbackDeploy(ctx, run)
+
+		var deployErr *ProjectDeploymentError
+		if errors.As(err, &deployErr) {
+			deployErr.rollbacks = rollbacks
+		}
+	}
+
+	// contracts deployed before a failure are recorded as well
+	saveErr := state.SaveLockfile(lock)
+	if err != nil {
+		return nil, err
+	}
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	return deployed, nil
+}
+
+// deploySorted deploys the contracts one by one in the deployment order.
+//
+// Contracts importing a contract that failed, or whose hooks failed, are skipped the same way as in deployLevels.
+func (f *Flowkit) deploySorted(
+	ctx context.Context,
+	state *State,
+	deployment *project.Deployment,
+	run *projectDeploy,
+) ([]*project.Contract, error) {
 	sorted, err := deployment.Sort()
 	if err != nil {
 		return nil, err
@@ -716,40 +1149,40 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 	defer f.logger.StopProgress()
 
 	deployErr := &ProjectDeploymentError{}
+	// contracts that failed or were skipped, so the contracts importing them are skipped as well
+	failed := make(map[string]bool)
 	for _, contract := range sorted {
+		if dependsOnFailed(deployment.Dependencies(contract.Name), failed) {
+			failed[contract.Name] = true
+			deployErr.add(contract, errDependencyFailed, fmt.Sprintf("skipped deploying contract %s", contract.Name))
+			continue
+		}
+
 		targetAccount, err := state.Accounts().ByName(contract.AccountName)
 		if err != nil {
 			return nil, fmt.Errorf("target account for deploying contract not found in configuration")
 		}
 
-		txID, updated, err := f.AddContract(
-			ctx,
-			targetAccount,
-			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
-			update,
-		)
-		if err != nil && errors.Is(err, errUpdateNoDiff) {
-			f.logger.Info(fmt.Sprintf(
-				"%s -> 0x%s [skipping, no changes found]",
-				output.Italic(contract.Name),
-				contract.AccountAddress.String(),
-			))
-			continue
-		} else if err != nil {
+		hooks, err := f.beforeContractHooks(ctx, state, contract, run)
+		if err != nil {
+			failed[contract.Name] = true
 			deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
-			continue
+		} else if !f.runDeployHooks(ctx, state, hooks, deployErr) {
+			failed[contract.Name] = true
+		} else if err = f.deployContract(ctx, targetAccount, contract, run); err != nil {
+			failed[contract.Name] = true
+			deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
+		} else if !f.runDeployHooks(ctx, state, run.contractHooks(contract), deployErr) {
+			failed[contract.Name] = true
 		}
 
-		f.logger.Info(fmt.Sprintf(
-			"%s -> 0x%s (%s) %s",
-			output.Green(contract.Name),
-			contract.AccountAddress,
-			txID.String(),
-			map[bool]string{true: "[updated]", false: ""}[updated],
-		))
+		// contracts deployed so far are rolled back, so there is no point in deploying the rest
+		if deployErr.failed() && run.options.atomic {
+			break
+		}
 	}
 
-	if len(deployErr.contracts) > 0 {
+	if deployErr.failed() {
 		return nil, deployErr
 	}
 
@@ -757,8 +1190,98 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 	return sorted, nil
 }
 
+// deployContract adds or updates the contract on the target account, records it in the lockfile and logs the outcome.
+//
+// Contracts that are already deployed without changes are skipped, and unless the lockfile is ignored the target
+// account isn't fetched for contracts whose code and address match the lockfile.
+func (f *Flowkit) deployContract(
+	ctx context.Context,
+	targetAccount *accounts.Account,
+	contract *project.Contract,
+	run *projectDeploy,
+) error {
+	state, err := f.State()
+	if err != nil {
+		return err
+	}
+
+	script := Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()}
+	program, name, err := f.resolveContract(state, script)
+	if err != nil {
+		return err
+	}
+
+	entry := newLockedContract(contract, program)
+	locked, sameAsLocked := lockedContract(run.lock, f.network.Name, contract.Name, entry)
+
+	if sameAsLocked && !run.options.ignoreLockfile {
+		f.logger.Info(fmt.Sprintf(
+			"%s -> 0x%s [skipping, unchanged since last deploy]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
+		))
+		return nil
+	}
+
+	added, err := f.addContract(ctx, state, targetAccount, script, program, name, run.update, !run.options.skipUpdateCheck)
+	if err != nil && errors.Is(err, errUpdateNoDiff) {
+		// keep the transaction of the previous deploy if it deployed the same code
+		if sameAsLocked {
+			entry.TransactionID, entry.BlockID, entry.BlockHeight = locked.TransactionID, locked.BlockID, locked.BlockHeight
+		}
+		run.lock.SetContract(f.network.Name, contract.Name, entry)
+
+		f.logger.Info(fmt.Sprintf(
+			"%s -> 0x%s [skipping, no changes found]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
+		))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	previousEntry, wasLocked := run.lock.Contract(f.network.Name, contract.Name)
+	run.record(contractChange{
+		account:       targetAccount,
+		contract:      contract,
+		name:          name,
+		existed:       added.existed,
+		previous:      added.previous,
+		previousEntry: previousEntry,
+		wasLocked:     wasLocked,
+	})
+
+	entry.TransactionID = added.txID.String()
+	entry.BlockID = added.result.BlockID.String()
+	entry.BlockHeight = added.result.BlockHeight
+	run.lock.SetContract(f.network.Name, contract.Name, entry)
+
+	f.logger.Info(fmt.Sprintf(
+		"%s -> 0x%s (%s) %s",
+		output.Green(contract.Name),
+		contract.AccountAddress,
+		added.txID.String(),
+		map[bool]string{true: "[updated]", false: ""}[added.updated],
+	))
+	return nil
+}
+
 type ProjectDeploymentError struct {
 	contracts map[string]error
+	hooks     map[string]error
+	rollbacks []ContractRollback
+}
+
+func (d *ProjectDeploymentError) failed() bool {
+	return len(d.contracts) > 0 || len(d.hooks) > 0
+}
+
+func (d *ProjectDeploymentError) addHook(hook deployHook, err error) {
+	if d.hooks == nil {
+		d.hooks = make(map[string]error)
+	}
+	d.hooks[hook.String()] = err
 }
 
 func (d *ProjectDeploymentError) add(contract *project.Contract, err error, msg string) {
@@ -772,11 +1295,25 @@ func (d *ProjectDeploymentError) Contracts() map[string]error {
 	return d.contracts
 }
 
+// Hooks returns the errors of the deployment hooks that failed, by the hook transaction and when it runs.
+func (d *ProjectDeploymentError) Hooks() map[string]error {
+	return d.hooks
+}
+
+// Rollbacks returns how the contracts deployed before the failure were rolled back, in the order they were rolled
+// back. It's empty unless the deploy was atomic.
+func (d *ProjectDeploymentError) Rollbacks() []ContractRollback {
+	return d.rollbacks
+}
+
 func (d *ProjectDeploymentError) Error() string {
 	err := ""
 	for c, e := range d.contracts {
 		err = fmt.Sprintf("%s %s: %s,", err, c, e.Error())
 	}
+	for h, e := range d.hooks {
+		err = fmt.Sprintf("%s deployment hook %s: %s,", err, h, e.Error())
+	}
 	return err
 }
 
@@ -791,7 +1328,7 @@ type Script struct {
 
 // ExecuteScript on the Flow network and return the Cadence value as a result. The script is executed at the
 // block provided as part of the ScriptQuery value.
-func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQuery) (cadence.Value, error) {
+func (f *Flowkit) ExecuteScript(ctx context.Context, script Script, query ScriptQuery) (cadence.Value, error) {
 	state, err := f.State()
 	if err != nil {
 		return nil, err
@@ -816,7 +1353,7 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 		if state == nil {
 			return nil, config.ErrDoesNotExist
 		}
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == config.EmptyNetwork.Name {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
 		}
 		if script.Location == "" {
@@ -830,46 +1367,48 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 	}
 
 	if query.Latest {
-		return f.gateway.ExecuteScript(program.Code(), script.Args)
+		return f.gateway.ExecuteScript(ctx, program.Code(), script.Args)
 	} else if query.ID != flow.EmptyID {
-		return f.gateway.ExecuteScriptAtID(program.Code(), script.Args, query.ID)
+		return f.gateway.ExecuteScriptAtID(ctx, program.Code(), script.Args, query.ID)
 	} else {
-		return f.gateway.ExecuteScriptAtHeight(program.Code(), script.Args, query.Height)
+		return f.gateway.ExecuteScriptAtHeight(ctx, program.Code(), script.Args, query.Height)
 	}
 }
 
-// GetTransactionByID from the Flow network including the transaction result. Using the waitSeal we can wait for the transaction to be sealed.
+// GetTransactionByID from the Flow network including the transaction result. Using the wait we can wait for the transaction
+// to reach the status defined by the wait strategy.
 func (f *Flowkit) GetTransactionByID(
-	_ context.Context,
+	ctx context.Context,
 	ID flow.Identifier,
-	waitSeal bool,
+	wait bool,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
 	f.logger.StartProgress("Fetching Transaction...")
 	defer f.logger.StopProgress()
 
-	tx, err := f.gateway.GetTransaction(ID)
+	tx, err := f.gateway.GetTransaction(ctx, ID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if waitSeal {
-		f.logger.StartProgress("Waiting for transaction to be sealed...")
+	if !wait {
+		result, err := f.gateway.GetTransactionResult(ctx, ID, false)
+		return tx, result, err
 	}
 
-	result, err := f.gateway.GetTransactionResult(ID, waitSeal)
+	result, err := f.waitForTransactionResult(ctx, ID)
 	return tx, result, err
 }
 
 func (f *Flowkit) GetTransactionsByBlockID(
-	_ context.Context,
+	ctx context.Context,
 	blockID flow.Identifier,
 ) ([]*flow.Transaction, []*flow.TransactionResult, error) {
-	tx, err := f.gateway.GetTransactionsByBlockID(blockID)
+	tx, err := f.gateway.GetTransactionsByBlockID(ctx, blockID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	txRes, err := f.gateway.GetTransactionResultsByBlockID(blockID)
+	txRes, err := f.gateway.GetTransactionResultsByBlockID(ctx, blockID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -880,27 +1419,46 @@ func (f *Flowkit) GetTransactionsByBlockID(
 //
 // AddressesRoles type defines the address for each role (payer, proposer, authorizers) and the script defines the transaction content.
 func (f *Flowkit) BuildTransaction(
-	_ context.Context,
+	ctx context.Context,
 	addresses transactions.AddressesRoles,
 	proposerKeyIndex int,
 	script Script,
 	gasLimit uint64,
 ) (*transactions.Transaction, error) {
-	state, err := f.State()
+	tx, err := f.newTransaction(ctx, addresses, script, gasLimit)
 	if err != nil {
 		return nil, err
 	}
 
-	latestBlock, err := f.gateway.GetLatestBlock()
+	proposerAccount, err := f.gateway.GetAccount(ctx, addresses.Proposer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
+		return nil, err
 	}
 
-	proposerAccount, err := f.gateway.GetAccount(addresses.Proposer)
+	if err := tx.SetProposer(proposerAccount, proposerKeyIndex); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// newTransaction builds the transaction with all the roles except the proposal key, which is set by the caller.
+func (f *Flowkit) newTransaction(
+	ctx context.Context,
+	addresses transactions.AddressesRoles,
+	script Script,
+	gasLimit uint64,
+) (*transactions.Transaction, error) {
+	state, err := f.State()
 	if err != nil {
 		return nil, err
 	}
 
+	latestBlock, err := f.gateway.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
+	}
+
 	tx := transactions.New().
 		SetPayer(addresses.Payer).
 		SetComputeLimit(gasLimit).
@@ -912,7 +1470,7 @@ func (f *Flowkit) BuildTransaction(
 	}
 
 	if program.HasImports() {
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == config.EmptyNetwork.Name {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in transaction code")
 		}
 		if script.Location == "" { // when used as lib with code we don't support imports
@@ -935,10 +1493,6 @@ func (f *Flowkit) BuildTransaction(
 		}
 	}
 
-	if err := tx.SetProposer(proposerAccount, proposerKeyIndex); err != nil {
-		return nil, err
-	}
-
 	if err := tx.SetScriptWithArgs(program.Code(), script.Args); err != nil {
 		return nil, err
 	}
@@ -976,15 +1530,15 @@ func (f *Flowkit) SignTransactionPayload(
 //
 // You can build the transaction using the BuildTransaction method and then sign it using the SignTranscation method.
 func (f *Flowkit) SendSignedTransaction(
-	_ context.Context,
+	ctx context.Context,
 	tx *transactions.Transaction,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return nil, nil, err
 	}
 
-	res, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	res, err := f.waitForTransactionResult(ctx, sentTx.ID())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1026,16 +1580,14 @@ func (f *Flowkit) SendTransaction(
 	f.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
 	f.logger.StartProgress("Sending transaction...")
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return nil, nil, err
 	}
 
 	f.logger.StopProgress()
-	f.logger.StartProgress("Waiting for transaction to be sealed...")
-	defer f.logger.StopProgress()
 
-	res, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	res, err := f.waitForTransactionResult(ctx, sentTx.ID())
 
 	return sentTx, res, err
 }
@@ -24,7 +24,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/transactions"
@@ -73,7 +75,7 @@ func newAccount(name string, address string, seed string) *accounts.Account {
 	}
 }
 
-func setup() (*State, Flowkit, *mocks.TestGateway) {
+func setup() (*State, *Flowkit, *mocks.TestGateway) {
 	readerWriter, _ := tests.ReaderWriter()
 	state, err := Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
 	if err != nil {
@@ -81,7 +83,7 @@ func setup() (*State, Flowkit, *mocks.TestGateway) {
 	}
 
 	gw := mocks.DefaultMockGateway()
-	flowkit := Flowkit{
+	flowkit := &Flowkit{
 		state:   state,
 		network: config.EmulatorNetwork,
 		gateway: gw.Mock,
@@ -110,7 +112,7 @@ func TestAccounts(t *testing.T) {
 		_, flowkit, gw := setup()
 		account, err := flowkit.GetAccount(ctx, serviceAddress)
 
-		gw.Mock.AssertCalled(t, "GetAccount", serviceAddress)
+		gw.Mock.AssertCalled(t, "GetAccount", mock.Anything, serviceAddress)
 		assert.NoError(t, err)
 		assert.Equal(t, serviceAddress, account.Address)
 	})
@@ -120,7 +122,7 @@ func TestAccounts(t *testing.T) {
 		newAddress := flow.HexToAddress("192440c99cb17282")
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, serviceAddress, tx.Authorizers[0])
 			assert.Equal(t, serviceAddress, tx.Payer)
 
@@ -129,7 +131,7 @@ func TestAccounts(t *testing.T) {
 
 		compareAddress := serviceAddress
 		gw.GetAccount.Run(func(args mock.Arguments) {
-			address := args.Get(0).(flow.Address)
+			address := args.Get(1).(flow.Address)
 			assert.Equal(t, address, compareAddress)
 			compareAddress = newAddress
 			gw.GetAccount.Return(
@@ -152,8 +154,8 @@ func TestAccounts(t *testing.T) {
 			}},
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, newAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, newAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -163,10 +165,61 @@ func TestAccounts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Create an Account wait timeout", func(t *testing.T) {
+		_, flowkit, gw := setup()
+		flowkit.SetWaitStrategy(WaitStrategy{PollInterval: time.Millisecond, Timeout: 10 * time.Millisecond})
+
+		gw.GetTransactionResult.Return(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		_, _, err := flowkit.CreateAccount(
+			ctx,
+			serviceAcc,
+			[]accounts.PublicKey{{
+				pubKey,
+				flow.AccountKeyWeightThreshold,
+				crypto.ECDSA_P256,
+				crypto.SHA3_256,
+			}},
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "was not sealed within 10ms")
+	})
+
+	t.Run("Create an Account waits until executed", func(t *testing.T) {
+		_, flowkit, gw := setup()
+		flowkit.SetWaitStrategy(WaitStrategy{Status: flow.TransactionStatusFinalized, PollInterval: time.Millisecond})
+		newAddress := flow.HexToAddress("192440c99cb17282")
+
+		// the finalized result doesn't have the events with the created address yet
+		results := []*flow.TransactionResult{
+			{Status: flow.TransactionStatusFinalized},
+			tests.NewAccountCreateResult(newAddress),
+		}
+		calls := 0
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			gw.GetTransactionResult.Return(results[calls], nil)
+			calls++
+		})
+
+		account, _, err := flowkit.CreateAccount(
+			ctx,
+			serviceAcc,
+			[]accounts.PublicKey{{
+				pubKey,
+				flow.AccountKeyWeightThreshold,
+				crypto.ECDSA_P256,
+				crypto.SHA3_256,
+			}},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, newAddress, account.Address)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
+	})
+
 	t.Run("Contract Add for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, serviceAddress)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -180,7 +233,7 @@ func TestAccounts(t *testing.T) {
 			UpdateExistingContract(false),
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, serviceAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -191,7 +244,7 @@ func TestAccounts(t *testing.T) {
 	t.Run("Contract Remove for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, serviceAddress)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.remove"))
 
@@ -199,7 +252,7 @@ func TestAccounts(t *testing.T) {
 		})
 
 		gw.GetAccount.Run(func(args mock.Arguments) {
-			addr := args.Get(0).(flow.Address)
+			addr := args.Get(1).(flow.Address)
 			assert.Equal(t, addr.String(), serviceAcc.Address.String())
 			racc := tests.NewAccountWithAddress(addr.String())
 			racc.Contracts = map[string][]byte{
@@ -215,7 +268,7 @@ func TestAccounts(t *testing.T) {
 			tests.ContractHelloString.Name,
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, serviceAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -224,7 +277,7 @@ func TestAccounts(t *testing.T) {
 	})
 }
 
-func setupIntegration() (*State, Flowkit) {
+func setupIntegration() (*State, *Flowkit) {
 	readerWriter, _ := tests.ReaderWriter()
 	state, err := Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
 	if err != nil {
@@ -241,7 +294,7 @@ func setupIntegration() (*State, Flowkit) {
 		emulator.WithTransactionExpiry(10),
 	))
 
-	flowkit := Flowkit{
+	flowkit := &Flowkit{
 		state:   state,
 		network: config.EmulatorNetwork,
 		gateway: gw,
@@ -658,7 +711,7 @@ func TestBlocks(t *testing.T) {
 
 		_, err := flowkit.GetBlock(ctx, BlockQuery{Latest: true})
 
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, mock.Anything)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByHeightFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByIDFunc)
 		assert.NoError(t, err)
@@ -675,7 +728,7 @@ func TestBlocks(t *testing.T) {
 
 		_, err := flowkit.GetBlock(ctx, BlockQuery{Height: 10})
 
-		gw.Mock.AssertCalled(t, mocks.GetBlockByHeightFunc, uint64(10))
+		gw.Mock.AssertCalled(t, mocks.GetBlockByHeightFunc, mock.Anything, uint64(10))
 		gw.Mock.AssertNotCalled(t, mocks.GetLatestBlockFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByIDFunc)
 		assert.NoError(t, err)
@@ -689,7 +742,7 @@ func TestBlocks(t *testing.T) {
 		_, err := flowkit.GetBlock(ctx, BlockQuery{ID: &ID})
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, mocks.GetBlockByIDFunc, ID)
+		gw.Mock.AssertCalled(t, mocks.GetBlockByIDFunc, mock.Anything, ID)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByHeightFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetLatestBlockFunc)
 	})
@@ -717,7 +770,7 @@ func TestCollections(t *testing.T) {
 		_, err := flowkit.GetCollection(ctx, ID)
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, "GetCollection", ID)
+		gw.Mock.AssertCalled(t, "GetCollection", mock.Anything, ID)
 	})
 }
 
@@ -729,7 +782,7 @@ func TestEvents(t *testing.T) {
 		_, err := flowkit.GetEvents(ctx, []string{"flow.CreateAccount"}, 0, 0, nil)
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, mocks.GetEventsFunc, "flow.CreateAccount", uint64(0), uint64(0))
+		gw.Mock.AssertCalled(t, mocks.GetEventsFunc, mock.Anything, "flow.CreateAccount", uint64(0), uint64(0))
 	})
 
 	t.Run("Should have larger endHeight then startHeight", func(t *testing.T) {
@@ -765,6 +818,82 @@ func TestEvents(t *testing.T) {
 		assert.EqualError(t, err, "failed getting event")
 	})
 
+	t.Run("Get Events cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+		gw.GetEvents.Return([]flow.BlockEvents{}, nil)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		worker := &EventWorker{Count: 2, BlocksPerWorker: 1}
+		_, err := flowkit.GetEvents(ctx, []string{"flow.CreateAccount"}, 0, 100, worker)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Subscribe Events", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+		latest := tests.NewBlock()
+		latest.Height = 10
+		gw.GetLatestBlock.Return(latest, nil)
+
+		gw.GetEvents.Run(func(args mock.Arguments) {
+			name := args.Get(1).(string)
+			var blockEvents []flow.BlockEvents
+			for height := args.Get(2).(uint64); height <= args.Get(3).(uint64); height++ {
+				blockEvents = append(blockEvents, flow.BlockEvents{
+					Height: height,
+					Events: []flow.Event{*tests.NewEvent(int(height), name, nil, nil)},
+				})
+			}
+			gw.GetEvents.Return(blockEvents, nil)
+		})
+
+		ctx, cancel := context.WithCancel(ctx)
+		events, errs, err := flowkit.SubscribeEvents(ctx, []string{"flow.AccountCreated", "flow.AccountKeyAdded"}, 9, nil)
+		require.NoError(t, err)
+
+		for _, height := range []uint64{9, 10} {
+			blockEvents := <-events
+			assert.Equal(t, height, blockEvents.Height)
+			require.Len(t, blockEvents.Events, 2)
+			assert.Equal(t, "flow.AccountCreated", blockEvents.Events[0].Type)
+		}
+
+		cancel()
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.NoError(t, <-errs)
+	})
+
+	t.Run("Subscribe Events error", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+		gw.GetEvents.Return(nil, errors.New("failed getting event"))
+
+		events, errs, err := flowkit.SubscribeEvents(ctx, []string{"flow.AccountCreated"}, 1, nil)
+		require.NoError(t, err)
+
+		assert.EqualError(t, <-errs, "failed getting event")
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("Subscribe Events invalid worker", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, _ := setup()
+
+		_, _, err := flowkit.SubscribeEvents(ctx, []string{"flow.AccountCreated"}, 1, &EventWorker{Count: 1})
+		assert.EqualError(t, err, "event worker blocks per worker must be at least 1, got 0")
+
+		_, err = flowkit.GetEvents(ctx, []string{"flow.AccountCreated"}, 1, 10, &EventWorker{BlocksPerWorker: 10})
+		assert.EqualError(t, err, "event worker count must be at least 1, got 0")
+	})
 }
 
 func TestEvents_Integration(t *testing.T) {
@@ -988,7 +1117,7 @@ func TestProject(t *testing.T) {
 		state.Deployments().AddOrUpdate(d)
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, acct2.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1052,7 +1181,7 @@ func TestProject(t *testing.T) {
 		} // don't change formatting of the above code since it compares the strings with included formatting
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, a.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1074,8 +1203,8 @@ func TestProject(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, a.Address)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, mock.Anything)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, a.Address)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
 	})
 
@@ -1129,7 +1258,7 @@ func TestProject(t *testing.T) {
 		} // don't change formatting of the above code since it compares the strings with included formatting
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, a.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1151,8 +1280,8 @@ func TestProject(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, a.Address)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, mock.Anything)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, mock.Anything, a.Address)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
 	})
 
@@ -1185,7 +1314,7 @@ func TestProject(t *testing.T) {
 		state.Deployments().AddOrUpdate(d)
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, acct2.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1199,10 +1328,644 @@ func TestProject(t *testing.T) {
 		assert.Equal(t, contracts[0].AccountAddress, acct2.Address)
 	})
 
+	t.Run("Deploy Project Concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractAA} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: config.EmulatorNetwork.Name,
+			Account: a.Name,
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractB.Name}, {Name: tests.ContractA.Name}, {Name: tests.ContractAA.Name},
+			},
+		})
+
+		// the account has two keys matching the account key, so two contracts can be deployed at the same time
+		pk, _ := a.Key.PrivateKey()
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: (*pk).PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: flow.AccountKeyWeightThreshold},
+			{Index: 1, PublicKey: (*pk).PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: flow.AccountKeyWeightThreshold},
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		var mu sync.Mutex
+		deployed := make(map[string]int)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(1).(*flow.Transaction)
+			name, _ := jsoncdc.Decode(nil, tx.Arguments[0])
+
+			mu.Lock()
+			deployed[name.ToGoValue().(string)] = tx.ProposalKey.KeyIndex
+			mu.Unlock()
+		}).Return(tests.NewTransaction(), nil)
+
+		contracts, err := flowkit.DeployProject(ctx, UpdateExistingContract(false), WithDeployConcurrency(2))
+		require.NoError(t, err)
+		require.Len(t, contracts, 3)
+		assert.Equal(t, tests.ContractB.Name, contracts[2].Name)
+
+		// contracts without imports are deployed in the first level with different proposal keys
+		require.Len(t, deployed, 3)
+		assert.NotEqual(t, deployed[tests.ContractA.Name], deployed[tests.ContractAA.Name])
+	})
+
+	t.Run("Deploy Project Fail", func(t *testing.T) {
+		t.Parallel()
+
+		// sequential and concurrent deploys skip the same contracts
+		for _, concurrency := range []int{1, 2} {
+			concurrency := concurrency
+			t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+				t.Parallel()
+
+				state, flowkit, gw := setup()
+				state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+				a := Alice()
+				state.Accounts().AddOrUpdate(a)
+
+				resources := []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractAA, tests.ContractBB}
+				deployments := make([]config.ContractDeployment, len(resources))
+				for i, c := range resources {
+					state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+					deployments[i] = config.ContractDeployment{Name: c.Name}
+				}
+				state.Deployments().AddOrUpdate(config.Deployment{
+					Network:   config.EmulatorNetwork.Name,
+					Account:   a.Name,
+					Contracts: deployments,
+				})
+
+				// a single key deploys one contract at a time, so the mocked result can be set for each transaction
+				pk, _ := a.Key.PrivateKey()
+				account := tests.NewAccountWithAddress(a.Address.String())
+				account.Keys = []*flow.AccountKey{
+					{Index: 0, PublicKey: (*pk).PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: flow.AccountKeyWeightThreshold},
+				}
+				gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+				var sent []string
+				gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+					tx := args.Get(1).(*flow.Transaction)
+					name, _ := jsoncdc.Decode(nil, tx.Arguments[0])
+					sent = append(sent, name.ToGoValue().(string))
+
+					if name.ToGoValue().(string) == tests.ContractA.Name {
+						gw.SendSignedTransaction.Return(nil, fmt.Errorf("failed"))
+					} else {
+						gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+					}
+				})
+
+				_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false), WithDeployConcurrency(concurrency))
+				var deployErr *ProjectDeploymentError
+				require.ErrorAs(t, err, &deployErr)
+				require.Len(t, deployErr.Contracts(), 2)
+				assert.ErrorContains(t, deployErr.Contracts()[tests.ContractA.Name], "failed to deploy contract ContractA")
+				assert.ErrorIs(t, deployErr.Contracts()[tests.ContractB.Name], errDependencyFailed)
+
+				// only the contract importing the failed contract is skipped
+				assert.NotContains(t, deployErr.Contracts(), tests.ContractAA.Name)
+				assert.NotContains(t, deployErr.Contracts(), tests.ContractBB.Name)
+				assert.Len(t, sent, 3)
+			})
+		}
+	})
+
+	t.Run("Deploy Project Concurrently Atomic Fail", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractAA, tests.ContractBB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractAA.Name}, {Name: tests.ContractBB.Name}},
+		})
+
+		pk, _ := a.Key.PrivateKey()
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: (*pk).PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: flow.AccountKeyWeightThreshold},
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {}).Return(nil, fmt.Errorf("failed"))
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false), WithDeployConcurrency(2), WithAtomicDeploy())
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+
+		// the next level is not deployed since the deployed contracts are rolled back
+		assert.NotContains(t, deployErr.Contracts(), tests.ContractBB.Name)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 2)
+	})
+
+	t.Run("Plan Deployment", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractAA} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: config.EmulatorNetwork.Name,
+			Account: a.Name,
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}, {Name: tests.ContractAA.Name},
+			},
+		})
+
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name:  tests.ContractA.Source,
+			tests.ContractAA.Name: []byte(`pub contract ContractAA { pub let x: Int; init() { self.x = 1 } }`),
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		plans, err := flowkit.PlanDeployment(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+		require.Len(t, plans, 3)
+
+		actions := make(map[string]ContractPlan)
+		for _, plan := range plans {
+			actions[plan.Contract.Name] = plan
+		}
+
+		assert.Equal(t, ContractUnchanged, actions[tests.ContractA.Name].Action)
+		assert.Equal(t, ContractAdd, actions[tests.ContractB.Name].Action)
+		assert.Equal(t, map[string]flow.Address{tests.ContractA.Name: a.Address}, actions[tests.ContractB.Name].Imports)
+		assert.Equal(t, ContractBlocked, actions[tests.ContractAA.Name].Action)
+		assert.NotEmpty(t, actions[tests.ContractAA.Name].Reason)
+
+		plans, err = flowkit.PlanDeployment(ctx, UpdateExistingContract(true))
+		require.NoError(t, err)
+		for _, plan := range plans {
+			if plan.Contract.Name == tests.ContractAA.Name {
+				assert.Equal(t, ContractUpdate, plan.Action)
+			}
+		}
+
+		// the account is fetched once for each planning and no transactions are sent
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
+		gw.Mock.AssertNotCalled(t, mocks.SendSignedTransactionFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Deploy Project Lockfile", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		d := Donald()
+		state.Accounts().AddOrUpdate(d)
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+		})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   d.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractHelloString.Name}},
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+
+		lock, err := state.Lockfile()
+		require.NoError(t, err)
+		locked, ok := lock.Contract(config.EmulatorNetwork.Name, tests.ContractHelloString.Name)
+		require.True(t, ok)
+		assert.Equal(t, d.Name, locked.Account)
+		assert.Equal(t, "0x"+d.Address.Hex(), locked.Address)
+		assert.Equal(t, HashCode(tests.ContractHelloString.Source), locked.SourceHash)
+		assert.Equal(t, uint64(42), locked.BlockHeight)
+		assert.NotEmpty(t, locked.TransactionID)
+
+		// the recorded block is still on the network, so the unchanged contract is skipped without fetching the account
+		gw.GetBlockByHeight.Return(&flow.Block{BlockHeader: flow.BlockHeader{
+			ID:     flow.HexToID(locked.BlockID),
+			Height: locked.BlockHeight,
+		}}, nil)
+		calls := len(gw.Mock.Calls)
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetBlockByHeightFunc, 1)
+		assert.Len(t, gw.Mock.Calls, calls+1)
+
+		// the contract is deployed again when the lockfile is ignored
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(false), WithIgnoreLockfile())
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 2)
+	})
+
+	t.Run("Deploy Project Stale Lockfile", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		d := Donald()
+		state.Accounts().AddOrUpdate(d)
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+		})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   d.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractHelloString.Name}},
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+
+		// the recorded block isn't found, for example after the emulator was restarted
+		gw.GetBlockByHeight.Return(nil, fmt.Errorf("block not found"))
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 2)
+	})
+
+	t.Run("Deployment Status", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+		})
+
+		lock := NewLockfile()
+		lock.SetContract(config.EmulatorNetwork.Name, tests.ContractA.Name, LockedContract{
+			Account:    a.Name,
+			Address:    "0x" + a.Address.Hex(),
+			SourceHash: HashCode(tests.ContractA.Source),
+			CodeHash:   HashCode(tests.ContractA.Source),
+		})
+		lock.SetContract(config.EmulatorNetwork.Name, "Removed", LockedContract{
+			Account:  a.Name,
+			Address:  "0x" + a.Address.Hex(),
+			CodeHash: HashCode([]byte("pub contract Removed {}")),
+		})
+		require.NoError(t, state.SaveLockfile(lock))
+
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name: tests.ContractA.Source,
+			"Removed":            []byte("pub contract Removed { pub let x: Int; init() { self.x = 1 } }"),
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		statuses, err := flowkit.DeploymentStatus(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+
+		assert.Equal(t, tests.ContractA.Name, statuses[0].Name)
+		assert.False(t, statuses[0].Drifted())
+
+		assert.Equal(t, tests.ContractB.Name, statuses[1].Name)
+		assert.Nil(t, statuses[1].Locked)
+		assert.False(t, statuses[1].OnChain)
+
+		assert.Equal(t, "Removed", statuses[2].Name)
+		assert.False(t, statuses[2].Configured)
+		assert.True(t, statuses[2].OnChainChanged)
+
+		// the account is fetched once for all its contracts
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 1)
+	})
+
+	t.Run("Deploy Project Invalid Update", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractAA.Name, Location: tests.ContractAA.Filename})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractAA.Name}},
+		})
+
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractAA.Name: []byte(`pub contract ContractAA { pub resource R {} }`),
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(true))
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		var updateErr *InvalidContractUpdateError
+		require.ErrorAs(t, deployErr.Contracts()[tests.ContractAA.Name], &updateErr)
+		assert.Equal(t, tests.ContractAA.Name, updateErr.Contract)
+		require.Len(t, updateErr.Issues, 1)
+		gw.Mock.AssertNotCalled(t, mocks.SendSignedTransactionFunc, mock.Anything, mock.Anything)
+
+		// the update is left to the network to validate
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(true), WithoutUpdateCheck())
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("Deploy Project Hooks", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+			Hooks: []config.DeploymentHook{{
+				Transaction: tests.TransactionArgString.Filename,
+				Args:        []cadence.Value{cadence.String("admin")},
+				Contract:    tests.ContractA.Name,
+			}, {
+				Transaction: tests.TransactionSimple.Filename,
+			}},
+		})
+
+		var sent []*flow.Transaction
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(1).(*flow.Transaction))
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+
+		// the contract hook runs right after its contract and the deployment hook after all contracts
+		require.Len(t, sent, 4)
+		assert.Equal(t, string(tests.TransactionArgString.Source), string(sent[1].Script))
+		assert.Equal(t, []flow.Address{a.Address}, sent[1].Authorizers)
+		assert.Equal(t, string(tests.TransactionSimple.Source), string(sent[3].Script))
+
+		// hooks don't run again when the contracts didn't change
+		lock, err := state.Lockfile()
+		require.NoError(t, err)
+		locked, ok := lock.Contract(config.EmulatorNetwork.Name, tests.ContractB.Name)
+		require.True(t, ok)
+		gw.GetBlockByHeight.Return(&flow.Block{BlockHeader: flow.BlockHeader{
+			ID:     flow.HexToID(locked.BlockID),
+			Height: locked.BlockHeight,
+		}}, nil)
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+		assert.Len(t, sent, 4)
+	})
+
+	t.Run("Deploy Project Failed Hook", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, _ := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractA.Name, Location: tests.ContractA.Filename})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+			Hooks:     []config.DeploymentHook{{Transaction: "missing.cdc", Contract: tests.ContractA.Name}},
+		})
+		state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractB.Name, Location: tests.ContractB.Filename})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Contracts(), 1)
+		assert.ErrorIs(t, deployErr.Contracts()[tests.ContractB.Name], errDependencyFailed)
+		require.Len(t, deployErr.Hooks(), 1)
+		assert.Contains(t, deployErr.Hooks()["missing.cdc after contract ContractA"].Error(), "failed to read transaction missing.cdc")
+	})
+
+	t.Run("Deploy Project Before Hooks", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+			Hooks: []config.DeploymentHook{{
+				Transaction: tests.TransactionArgString.Filename,
+				Args:        []cadence.Value{cadence.String("admin")},
+				Contract:    tests.ContractB.Name,
+				Before:      true,
+			}, {
+				Transaction: tests.TransactionSimple.Filename,
+				Before:      true,
+			}},
+		})
+
+		var sent []*flow.Transaction
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(1).(*flow.Transaction))
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+
+		// the deployment hook runs before all contracts and the contract hook right before its contract
+		require.Len(t, sent, 4)
+		assert.Equal(t, string(tests.TransactionSimple.Source), string(sent[0].Script))
+		assert.Equal(t, string(tests.TransactionArgString.Source), string(sent[2].Script))
+
+		// hooks don't run again when the contracts didn't change
+		lock, err := state.Lockfile()
+		require.NoError(t, err)
+		locked, ok := lock.Contract(config.EmulatorNetwork.Name, tests.ContractB.Name)
+		require.True(t, ok)
+		gw.GetBlockByHeight.Return(&flow.Block{BlockHeader: flow.BlockHeader{
+			ID:     flow.HexToID(locked.BlockID),
+			Height: locked.BlockHeight,
+		}}, nil)
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		require.NoError(t, err)
+		assert.Len(t, sent, 4)
+	})
+
+	t.Run("Deploy Project Failed Before Hook", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+			Hooks:     []config.DeploymentHook{{Transaction: "missing.cdc", Contract: tests.ContractA.Name, Before: true}},
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(false))
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Contracts(), 1)
+		assert.ErrorIs(t, deployErr.Contracts()[tests.ContractB.Name], errDependencyFailed)
+		require.Len(t, deployErr.Hooks(), 1)
+		assert.Contains(t, deployErr.Hooks()["missing.cdc before contract ContractA"].Error(), "failed to read transaction missing.cdc")
+
+		// the contract is not deployed when its hook fails
+		gw.Mock.AssertNotCalled(t, mocks.SendSignedTransactionFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Deploy Project Atomic", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+		})
+
+		// ContractA is added, then updating ContractB breaks the contract update rules
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractB.Name: []byte(`pub contract ContractB { pub resource R {} }`),
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		var sent []*flow.Transaction
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(1).(*flow.Transaction))
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(true), WithAtomicDeploy())
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Rollbacks(), 1)
+		rollback := deployErr.Rollbacks()[0]
+		assert.Equal(t, tests.ContractA.Name, rollback.Contract.Name)
+		assert.True(t, rollback.Removed)
+		assert.NoError(t, rollback.Error)
+		require.Len(t, sent, 2)
+		assert.Contains(t, string(sent[1].Script), "contracts.remove")
+
+		lock, err := state.Lockfile()
+		require.NoError(t, err)
+		_, ok := lock.Contract(config.EmulatorNetwork.Name, tests.ContractA.Name)
+		assert.False(t, ok)
+
+		// the previous code of an updated contract is restored
+		previous := []byte(`pub contract ContractA { pub fun hello() {} }`)
+		account.Contracts[tests.ContractA.Name] = previous
+		sent = nil
+
+		_, err = flowkit.DeployProject(ctx, UpdateExistingContract(true), WithAtomicDeploy())
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Rollbacks(), 1)
+		assert.False(t, deployErr.Rollbacks()[0].Removed)
+		assert.NoError(t, deployErr.Rollbacks()[0].Error)
+		require.Len(t, sent, 2)
+		assert.Contains(t, string(sent[1].Script), "contracts.update")
+		assert.Contains(t, string(sent[1].Arguments[1]), hex.EncodeToString(previous))
+	})
+
+	t.Run("Deploy Project Atomic Live Network", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+		flowkit.network = config.TestnetNetwork
+		state.Networks().AddOrUpdate(config.TestnetNetwork)
+
+		a := Alice()
+		state.Accounts().AddOrUpdate(a)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.TestnetNetwork.Name,
+			Account:   a.Name,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+		})
+
+		account := tests.NewAccountWithAddress(a.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractB.Name: []byte(`pub contract ContractB { pub resource R {} }`),
+		}
+		gw.GetAccount.Run(func(args mock.Arguments) {}).Return(account, nil)
+
+		_, err := flowkit.DeployProject(ctx, UpdateExistingContract(true), WithAtomicDeploy())
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Rollbacks(), 1)
+		assert.ErrorIs(t, deployErr.Rollbacks()[0].Error, errContractRemovalRestricted)
+
+		// the added contract isn't removed, so it remains in the lockfile
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
+		lock, err := state.Lockfile()
+		require.NoError(t, err)
+		_, ok := lock.Contract(config.TestnetNetwork.Name, tests.ContractA.Name)
+		assert.True(t, ok)
+	})
 }
 
 // used for integration tests
-func simpleDeploy(state *State, flowkit Flowkit, update bool) ([]*project.Contract, error) {
+func simpleDeploy(state *State, flowkit *Flowkit, update bool) ([]*project.Contract, error) {
 	srvAcc, _ := state.EmulatorServiceAccount()
 
 	c := config.Contract{
@@ -1333,8 +2096,8 @@ func TestScripts(t *testing.T) {
 		_, flowkit, gw := setup()
 
 		gw.ExecuteScript.Run(func(args mock.Arguments) {
-			assert.Len(t, string(args.Get(0).([]byte)), 78)
-			assert.Equal(t, "\"Foo\"", args.Get(1).([]cadence.Value)[0].String())
+			assert.Len(t, string(args.Get(1).([]byte)), 78)
+			assert.Equal(t, "\"Foo\"", args.Get(2).([]cadence.Value)[0].String())
 			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 		})
 
@@ -1474,7 +2237,36 @@ func TestTransactions(t *testing.T) {
 
 		assert.NoError(t, err)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
-		gw.Mock.AssertCalled(t, mocks.GetTransactionFunc, txs.ID())
+		gw.Mock.AssertCalled(t, mocks.GetTransactionFunc, mock.Anything, txs.ID())
+	})
+
+	t.Run("Get Transaction wait for executed", func(t *testing.T) {
+		t.Parallel()
+		_, flowkit, gw := setup()
+		flowkit.SetWaitStrategy(WaitStrategy{Status: flow.TransactionStatusExecuted, PollInterval: time.Millisecond})
+
+		statuses := []flow.TransactionStatus{flow.TransactionStatusFinalized, flow.TransactionStatusExecuted}
+		calls := 0
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			gw.GetTransactionResult.Return(&flow.TransactionResult{Status: statuses[calls]}, nil)
+			calls++
+		})
+
+		_, result, err := flowkit.GetTransactionByID(ctx, tests.NewTransaction().ID(), true)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Status)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
+	})
+
+	t.Run("Get Transaction wait timeout", func(t *testing.T) {
+		t.Parallel()
+		_, flowkit, gw := setup()
+		flowkit.SetWaitStrategy(WaitStrategy{PollInterval: time.Millisecond, Timeout: 10 * time.Millisecond})
+		gw.GetTransactionResult.Return(&flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		txID := tests.NewTransaction().ID()
+		_, _, err := flowkit.GetTransactionByID(ctx, txID, true)
+		assert.EqualError(t, err, fmt.Sprintf("transaction %s was not sealed within 10ms", txID))
 	})
 
 	t.Run("Send Transaction args", func(t *testing.T) {
@@ -1483,7 +2275,7 @@ func TestTransactions(t *testing.T) {
 
 		var txID flow.Identifier
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			arg, err := tx.Argument(0)
 			assert.NoError(t, err)
 			assert.Equal(t, "\"Bar\"", arg.String())
@@ -1496,7 +2288,7 @@ func TestTransactions(t *testing.T) {
 		})
 
 		gw.GetTransactionResult.Run(func(args mock.Arguments) {
-			assert.Equal(t, txID, args.Get(0).(flow.Identifier))
+			assert.Equal(t, txID, args.Get(1).(flow.Identifier))
 			gw.GetTransactionResult.Return(tests.NewTransactionResult(nil), nil)
 		})
 
@@ -1517,13 +2309,13 @@ func TestTransactions(t *testing.T) {
 
 }
 
-func setupAccounts(state *State, flowkit Flowkit) {
+func setupAccounts(state *State, flowkit *Flowkit) {
 	setupAccount(state, flowkit, Alice())
 	setupAccount(state, flowkit, Bob())
 	setupAccount(state, flowkit, Charlie())
 }
 
-func setupAccount(state *State, flowkit Flowkit, account *accounts.Account) {
+func setupAccount(state *State, flowkit *Flowkit, account *accounts.Account) {
 	srv, _ := state.EmulatorServiceAccount()
 
 	key := account.Key
@@ -2072,6 +2864,159 @@ func TestTransactions_Integration(t *testing.T) {
 		assert.Nil(t, txr.Error)
 		assert.Equal(t, txr.Status, flow.TransactionStatusSealed)
 	})
+
+	t.Run("Send Transactions", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		srv, _ := state.EmulatorServiceAccount()
+
+		// the proposer account has three keys with the same public key, so three transactions can be sent concurrently
+		proposer := Alice()
+		pk, _ := proposer.Key.PrivateKey()
+		publicKey := accounts.PublicKey{
+			Public:   (*pk).PublicKey(),
+			Weight:   flow.AccountKeyWeightThreshold,
+			SigAlgo:  proposer.Key.SigAlgo(),
+			HashAlgo: proposer.Key.HashAlgo(),
+		}
+		account, _, err := flowkit.CreateAccount(ctx, srv, []accounts.PublicKey{publicKey, publicKey, publicKey})
+		require.NoError(t, err)
+		proposer.Address = account.Address
+
+		batch := make([]BatchTransaction, 9)
+		for i := range batch {
+			batch[i] = BatchTransaction{
+				Accounts: transactions.AccountRoles{
+					Proposer:    *proposer,
+					Authorizers: []accounts.Account{*srv},
+					Payer:       *srv,
+				},
+				Script: Script{
+					Code:     tests.TransactionSingleAuth.Source,
+					Location: tests.TransactionSingleAuth.Filename,
+				},
+				GasLimit: flow.DefaultTransactionGasLimit,
+			}
+		}
+
+		results, err := flowkit.SendTransactions(ctx, batch, 3)
+		require.NoError(t, err)
+		require.Len(t, results, len(batch))
+		for _, result := range results {
+			require.NoError(t, result.Error)
+			assert.Nil(t, result.Result.Error)
+			assert.Equal(t, flow.TransactionStatusSealed, result.Result.Status)
+			assert.Equal(t, proposer.Address, result.Tx.ProposalKey.Address)
+		}
+
+		account, err = flowkit.GetAccount(ctx, proposer.Address)
+		require.NoError(t, err)
+		sequence := uint64(0)
+		for _, key := range account.Keys {
+			sequence += key.SequenceNumber
+		}
+		assert.Equal(t, uint64(len(batch)), sequence)
+
+		_, err = flowkit.SendTransactions(ctx, batch, 0)
+		assert.EqualError(t, err, "invalid concurrency 0, must be at least 1")
+	})
+
+	t.Run("Simulate Transaction", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		srv, _ := state.EmulatorServiceAccount()
+
+		before, err := flowkit.GetAccount(ctx, srv.Address)
+		require.NoError(t, err)
+
+		tx, result, err := flowkit.SimulateTransaction(
+			ctx,
+			transactions.SingleAccountRole(*srv),
+			Script{
+				Code: []byte(`
+					transaction() {
+						prepare(authorizer: AuthAccount) {
+							log(authorizer.address)
+						}
+					}`),
+				Location: "simulate.cdc",
+			},
+			flow.DefaultTransactionGasLimit,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, srv.Address, tx.Payer)
+		assert.Nil(t, result.Result.Error)
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Result.Status)
+		assert.Equal(t, []string{fmt.Sprintf("0x%s", srv.Address)}, result.Logs)
+
+		// the transaction is not sent so the proposal key is not used
+		after, err := flowkit.GetAccount(ctx, srv.Address)
+		require.NoError(t, err)
+		assert.Equal(t, before.Keys[0].SequenceNumber, after.Keys[0].SequenceNumber)
+	})
+
+	t.Run("Estimate Transaction", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		srv, _ := state.EmulatorServiceAccount()
+
+		script := func(iterations int) Script {
+			return Script{
+				Code: []byte(fmt.Sprintf(`
+					transaction() {
+						prepare(authorizer: AuthAccount) {
+							var i = 0
+							while i < %d { i = i + 1 }
+						}
+					}`, iterations)),
+				Location: "estimate.cdc",
+			}
+		}
+
+		small, err := flowkit.EstimateTransaction(ctx, transactions.SingleAccountRole(*srv), script(10))
+		require.NoError(t, err)
+		assert.Greater(t, small.Fee, cadence.UFix64(0))
+
+		large, err := flowkit.EstimateTransaction(ctx, transactions.SingleAccountRole(*srv), script(1000))
+		require.NoError(t, err)
+		assert.Greater(t, large.ComputationUsed, small.ComputationUsed)
+
+		_, err = flowkit.EstimateTransaction(ctx, transactions.SingleAccountRole(*srv), Script{
+			Code:     []byte(`transaction() { prepare(authorizer: AuthAccount) { panic("failed") } }`),
+			Location: "estimate.cdc",
+		})
+		assert.ErrorContains(t, err, "failed to estimate transaction, simulation failed")
+	})
+
+	t.Run("Get Transactions by Block ID", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		setupAccounts(state, flowkit)
+
+		a, _ := state.Accounts().ByName("Alice")
+
+		tx, txr, err := flowkit.SendTransaction(
+			ctx,
+			transactions.SingleAccountRole(*a),
+			Script{
+				Code:     tests.TransactionSingleAuth.Source,
+				Location: tests.TransactionSingleAuth.Filename,
+			},
+			flow.DefaultTransactionGasLimit,
+		)
+		require.NoError(t, err)
+
+		txs, txResults, err := flowkit.GetTransactionsByBlockID(ctx, txr.BlockID)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		require.Len(t, txResults, 1)
+		assert.Equal(t, tx.ID(), txs[0].ID())
+		assert.Equal(t, tx.ID(), txResults[0].TransactionID)
+		assert.Equal(t, flow.TransactionStatusSealed, txResults[0].Status)
+
+		_, _, err = flowkit.GetTransactionsByBlockID(ctx, flow.HexToID("01"))
+		assert.Error(t, err)
+	})
 }
 
 func Test_BlockQuery(t *testing.T) {
@@ -2092,3 +3037,17 @@ func Test_BlockQuery(t *testing.T) {
 	assert.EqualError(t, err, "invalid query: invalid, valid are: \"latest\", block height or block ID")
 
 }
+
+func Test_WaitStrategy(t *testing.T) {
+	w, err := NewWaitStrategy("executed")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.TransactionStatusExecuted, w.Status)
+	assert.Equal(t, DefaultWaitStrategy.PollInterval, w.PollInterval)
+
+	w, err = NewWaitStrategy("Finalized")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.TransactionStatusFinalized, w.Status)
+
+	_, err = NewWaitStrategy("pending")
+	assert.EqualError(t, err, "invalid transaction status: pending, valid are: \"finalized\", \"executed\" or \"sealed\"")
+}
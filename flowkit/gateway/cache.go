@@ -0,0 +1,445 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DefaultCacheSize is the number of entries the caching gateway keeps in memory.
+const DefaultCacheSize = 1024
+
+// DefaultMaxDiskSize is the maximum size in bytes of the entries the caching gateway keeps on disk.
+const DefaultMaxDiskSize = 100 << 20
+
+var _ Gateway = &CachingGateway{}
+
+// CachingGateway is a gateway decorator that caches chain data which can no longer change.
+//
+// Blocks, collections and transactions are always cached, transaction results only once they are sealed,
+// and events and accounts at a block height only when the requested height is sealed. Entries are kept encoded in
+// an in-memory LRU cache and, if a cache directory is set, also persisted to disk so they can be reused between runs.
+// The least recently used entries on disk are removed when the disk cache exceeds its maximum size.
+// Every call decodes a new value, so callers can modify the returned values without changing the cache.
+type CachingGateway struct {
+	gateway      Gateway
+	size         int
+	dir          string
+	mu           sync.Mutex
+	entries      map[string]*list.Element
+	order        *list.List
+	sealedHeight uint64
+
+	maxDiskSize int64
+	diskMu      sync.Mutex
+	// diskSize is the size of the entries on disk, or -1 until the cache directory is scanned
+	diskSize int64
+}
+
+// WithCacheSize sets the maximum number of entries kept in memory.
+func WithCacheSize(size int) func(*CachingGateway) {
+	return func(g *CachingGateway) {
+		g.size = size
+	}
+}
+
+// WithCacheDir enables the on-disk cache stored in the provided directory.
+func WithCacheDir(dir string) func(*CachingGateway) {
+	return func(g *CachingGateway) {
+		g.dir = dir
+	}
+}
+
+// WithMaxDiskSize sets the maximum size in bytes of the on-disk cache, a size of zero or less disables the limit.
+func WithMaxDiskSize(size int64) func(*CachingGateway) {
+	return func(g *CachingGateway) {
+		g.maxDiskSize = size
+	}
+}
+
+// NewCachingGateway wraps the gateway with an immutable data cache.
+func NewCachingGateway(gateway Gateway, opts ...func(*CachingGateway)) *CachingGateway {
+	g := &CachingGateway{
+		gateway:     gateway,
+		size:        DefaultCacheSize,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		maxDiskSize: DefaultMaxDiskSize,
+		diskSize:    -1,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+type cacheEntry struct {
+	key  string
+	data []byte
+}
+
+// get returns the encoded value from the in-memory cache and marks it as recently used.
+func (g *CachingGateway) get(key string) ([]byte, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	el, ok := g.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	g.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).data, true
+}
+
+// add puts the encoded value in the in-memory cache, evicting the least recently used entries over the size limit.
+func (g *CachingGateway) add(key string, data []byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if el, ok := g.entries[key]; ok {
+		el.Value.(*cacheEntry).data = data
+		g.order.MoveToFront(el)
+		return
+	}
+
+	g.entries[key] = g.order.PushFront(&cacheEntry{key: key, data: data})
+	for g.size > 0 && g.order.Len() > g.size {
+		oldest := g.order.Back()
+		g.order.Remove(oldest)
+		delete(g.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (g *CachingGateway) path(key string) string {
+	return filepath.Join(g.dir, filepath.FromSlash(key)+".json")
+}
+
+// load reads the entry from the on-disk cache, any failure is treated as a cache miss.
+func (g *CachingGateway) load(key string) ([]byte, bool) {
+	if g.dir == "" {
+		return nil, false
+	}
+
+	path := g.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// the modification time orders the entries by when they were last used when the disk cache is pruned
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// store writes the entry to the on-disk cache, the cache is best effort so failures are ignored.
+func (g *CachingGateway) store(key string, data []byte) {
+	if g.dir == "" {
+		return
+	}
+
+	path := g.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	// write to a temporary file first so concurrent readers never see a partial entry
+	tmp := fmt.Sprintf("%s.%d.tmp", path, time.Now().UnixNano())
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return
+	}
+
+	g.addDiskUsage(int64(len(data)))
+}
+
+type diskEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// diskEntries returns the entries stored in the cache directory.
+func (g *CachingGateway) diskEntries() []diskEntry {
+	var entries []diskEntry
+	_ = filepath.WalkDir(g.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		entries = append(entries, diskEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return entries
+}
+
+// addDiskUsage adds the size of the written entry to the disk usage and, when the usage exceeds the maximum size,
+// removes the least recently used entries until the usage is below 90% of the maximum size,
+// so the cache isn't pruned again on every write.
+func (g *CachingGateway) addDiskUsage(written int64) {
+	g.diskMu.Lock()
+	defer g.diskMu.Unlock()
+
+	if g.diskSize < 0 {
+		// the first scan includes the written entry
+		g.diskSize = 0
+		for _, entry := range g.diskEntries() {
+			g.diskSize += entry.size
+		}
+	} else {
+		g.diskSize += written
+	}
+
+	if g.maxDiskSize <= 0 || g.diskSize <= g.maxDiskSize {
+		return
+	}
+
+	entries := g.diskEntries()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	g.diskSize = 0
+	for _, entry := range entries {
+		g.diskSize += entry.size
+	}
+	for _, entry := range entries {
+		if g.diskSize <= g.maxDiskSize/10*9 {
+			break
+		}
+		if err := os.Remove(entry.path); err == nil {
+			g.diskSize -= entry.size
+		}
+	}
+}
+
+// withCache returns the cached value for the key or fetches it, caching the result if it is immutable.
+func withCache[T any](
+	g *CachingGateway,
+	key string,
+	c codec[T],
+	immutable func(T) bool,
+	fetch func() (T, error),
+) (T, error) {
+	if data, ok := g.get(key); ok {
+		if value, err := c.decode(data); err == nil {
+			return value, nil
+		}
+	}
+
+	if data, ok := g.load(key); ok {
+		if value, err := c.decode(data); err == nil {
+			g.add(key, data)
+			return value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil || !immutable(value) {
+		return value, err
+	}
+
+	// the fetched value belongs to the caller, only the encoded copy is cached
+	if data, err := c.encode(value); err == nil {
+		g.add(key, data)
+		g.store(key, data)
+	}
+
+	return value, nil
+}
+
+func always[T any](T) bool {
+	return true
+}
+
+// isSealedHeight checks whether the height is sealed, refreshing the latest sealed height when needed.
+func (g *CachingGateway) isSealedHeight(ctx context.Context, height uint64) bool {
+	g.mu.Lock()
+	sealed := g.sealedHeight
+	g.mu.Unlock()
+
+	if height <= sealed {
+		return true
+	}
+
+	block, err := g.gateway.GetLatestBlock(ctx)
+	if err != nil {
+		return false
+	}
+
+	g.mu.Lock()
+	if block.Height > g.sealedHeight {
+		g.sealedHeight = block.Height
+	}
+	g.mu.Unlock()
+
+	return height <= block.Height
+}
+
+func (g *CachingGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return g.gateway.GetAccount(ctx, address)
+}
+
+// GetAccountAtBlockHeight only caches accounts at sealed heights, the account state at unsealed heights can still change.
+func (g *CachingGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	sealed := func(*flow.Account) bool {
+		return g.isSealedHeight(ctx, height)
+	}
+
+	return withCache(g, fmt.Sprintf("accounts/%s/%d", address, height), accountCodec, sealed,
+		func() (*flow.Account, error) {
+			return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+		},
+	)
+}
+
+func (g *CachingGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *CachingGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return withCache(g, fmt.Sprintf("transactions/%s", ID), jsonCodec[*flow.Transaction](), always[*flow.Transaction],
+		func() (*flow.Transaction, error) {
+			return g.gateway.GetTransaction(ctx, ID)
+		},
+	)
+}
+
+func (g *CachingGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return withCache(g, fmt.Sprintf("block-results/%s", blockID), resultsCodec, allSealed,
+		func() ([]*flow.TransactionResult, error) {
+			return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+		},
+	)
+}
+
+func (g *CachingGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return withCache(g, fmt.Sprintf("results/%s", ID), resultCodec, isSealed,
+		func() (*flow.TransactionResult, error) {
+			return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+		},
+	)
+}
+
+func (g *CachingGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return withCache(g, fmt.Sprintf("block-transactions/%s", blockID), jsonCodec[[]*flow.Transaction](), always[[]*flow.Transaction],
+		func() ([]*flow.Transaction, error) {
+			return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+		},
+	)
+}
+
+func (g *CachingGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return g.gateway.ExecuteScript(ctx, script, arguments)
+}
+
+func (g *CachingGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+}
+
+func (g *CachingGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+}
+
+func (g *CachingGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return g.gateway.GetLatestBlock(ctx)
+}
+
+func (g *CachingGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return withCache(g, fmt.Sprintf("heights/%d", height), jsonCodec[*flow.Block](), always[*flow.Block],
+		func() (*flow.Block, error) {
+			return g.gateway.GetBlockByHeight(ctx, height)
+		},
+	)
+}
+
+func (g *CachingGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return withCache(g, fmt.Sprintf("blocks/%s", ID), jsonCodec[*flow.Block](), always[*flow.Block],
+		func() (*flow.Block, error) {
+			return g.gateway.GetBlockByID(ctx, ID)
+		},
+	)
+}
+
+// GetEvents only caches events for height ranges that are already sealed, more events can be added to unsealed blocks.
+func (g *CachingGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	sealed := func([]flow.BlockEvents) bool {
+		return g.isSealedHeight(ctx, endHeight)
+	}
+
+	return withCache(g, fmt.Sprintf("events/%s/%d-%d", eventType, startHeight, endHeight), blockEventsCodec, sealed,
+		func() ([]flow.BlockEvents, error) {
+			return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+		},
+	)
+}
+
+func (g *CachingGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return withCache(g, fmt.Sprintf("collections/%s", ID), jsonCodec[*flow.Collection](), always[*flow.Collection],
+		func() (*flow.Collection, error) {
+			return g.gateway.GetCollection(ctx, ID)
+		},
+	)
+}
+
+func (g *CachingGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+}
+
+func (g *CachingGateway) Ping() error {
+	return g.gateway.Ping()
+}
+
+func (g *CachingGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+func isSealed(result *flow.TransactionResult) bool {
+	return result != nil && result.Status == flow.TransactionStatusSealed
+}
+
+func allSealed(results []*flow.TransactionResult) bool {
+	for _, result := range results {
+		if !isSealed(result) {
+			return false
+		}
+	}
+	return true
+}
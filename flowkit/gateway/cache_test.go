@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func TestCachingGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Cache blocks in memory", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		cache := gateway.NewCachingGateway(gw.Mock)
+		ID := flow.HexToID("01")
+
+		first, err := cache.GetBlockByID(ctx, ID)
+		require.NoError(t, err)
+		second, err := cache.GetBlockByID(ctx, ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetBlockByIDFunc, 1)
+	})
+
+	t.Run("Return copies of cached values", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		cache := gateway.NewCachingGateway(gw.Mock)
+		address := flow.HexToAddress("01")
+		block := tests.NewBlock()
+		block.Height = 100
+		gw.GetLatestBlock.Return(block, nil)
+
+		first, err := cache.GetBlockByHeight(ctx, 1)
+		require.NoError(t, err)
+		first.Height = 2
+		second, err := cache.GetBlockByHeight(ctx, 1)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Height, second.Height)
+
+		account, err := cache.GetAccountAtBlockHeight(ctx, address, 100)
+		require.NoError(t, err)
+		sequence := account.Keys[0].SequenceNumber
+		account.Keys[0].SequenceNumber++
+		account, err = cache.GetAccountAtBlockHeight(ctx, address, 100)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, account.Keys[0].SequenceNumber)
+
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetBlockByHeightFunc, 1)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountAtBlockHeightFunc, 1)
+	})
+
+	t.Run("Evict least recently used entries", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		cache := gateway.NewCachingGateway(gw.Mock, gateway.WithCacheSize(1))
+
+		_, _ = cache.GetBlockByHeight(ctx, 1)
+		_, _ = cache.GetBlockByHeight(ctx, 2)
+		_, _ = cache.GetBlockByHeight(ctx, 1)
+
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetBlockByHeightFunc, 3)
+	})
+
+	t.Run("Don't cache unsealed results", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		result := tests.NewTransactionResult(nil)
+		result.Status = flow.TransactionStatusExecuted
+		gw.GetTransactionResult.Return(result, nil)
+		cache := gateway.NewCachingGateway(gw.Mock)
+
+		_, _ = cache.GetTransactionResult(ctx, flow.HexToID("01"), false)
+		_, _ = cache.GetTransactionResult(ctx, flow.HexToID("01"), false)
+
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
+	})
+
+	t.Run("Only cache events for sealed heights", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		block := tests.NewBlock()
+		block.Height = 100
+		gw.GetLatestBlock.Return(block, nil)
+		cache := gateway.NewCachingGateway(gw.Mock)
+
+		for i := 0; i < 2; i++ {
+			_, _ = cache.GetEvents(ctx, "flow.AccountCreated", 0, 100)
+			_, _ = cache.GetEvents(ctx, "flow.AccountCreated", 101, 200)
+		}
+
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetEventsFunc, 3)
+	})
+
+	t.Run("Only cache accounts at sealed heights", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		block := tests.NewBlock()
+		block.Height = 100
+		gw.GetLatestBlock.Return(block, nil)
+		cache := gateway.NewCachingGateway(gw.Mock)
+		address := flow.HexToAddress("01")
+
+		for i := 0; i < 2; i++ {
+			account, err := cache.GetAccountAtBlockHeight(ctx, address, 100)
+			require.NoError(t, err)
+			assert.Equal(t, address, account.Address)
+			_, _ = cache.GetAccountAtBlockHeight(ctx, address, 101)
+		}
+
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountAtBlockHeightFunc, 3)
+	})
+
+	t.Run("Limit the disk cache size", func(t *testing.T) {
+		dir := t.TempDir()
+		gw := mocks.DefaultMockGateway()
+		cache := gateway.NewCachingGateway(gw.Mock, gateway.WithCacheDir(dir), gateway.WithMaxDiskSize(4096))
+
+		for height := uint64(1); height <= 50; height++ {
+			_, err := cache.GetBlockByHeight(ctx, height)
+			require.NoError(t, err)
+		}
+
+		var size int64
+		var entries int
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				info, _ := d.Info()
+				size += info.Size()
+				entries++
+			}
+			return err
+		})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, size, int64(4096))
+		assert.Greater(t, entries, 0)
+		assert.Less(t, entries, 50)
+	})
+
+	t.Run("Persist entries on disk", func(t *testing.T) {
+		dir := t.TempDir()
+		ID := flow.HexToID("01")
+		result := test.TransactionResultGenerator().New()
+
+		gw := mocks.DefaultMockGateway()
+		gw.GetTransactionResult.Return(&result, nil)
+		_, err := gateway.NewCachingGateway(gw.Mock, gateway.WithCacheDir(dir)).GetTransactionResult(ctx, ID, true)
+		require.NoError(t, err)
+
+		other := mocks.DefaultMockGateway()
+		cached, err := gateway.NewCachingGateway(other.Mock, gateway.WithCacheDir(dir)).GetTransactionResult(ctx, ID, true)
+		require.NoError(t, err)
+		other.Mock.AssertNotCalled(t, mocks.GetTransactionResultFunc)
+
+		assert.Equal(t, result.Status, cached.Status)
+		assert.EqualError(t, cached.Error, result.Error.Error())
+		assert.Equal(t, result.BlockID, cached.BlockID)
+		require.Len(t, cached.Events, len(result.Events))
+		assert.Equal(t, result.Events[0].Type, cached.Events[0].Type)
+		assert.Equal(t, result.Events[0].Value.String(), cached.Events[0].Value.String())
+	})
+}
@@ -0,0 +1,513 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Interaction is a single gateway call recorded in a cassette.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *RecordedError  `json:"error,omitempty"`
+}
+
+// RecordedError is an error returned by the gateway during recording.
+//
+// The gRPC status code is kept so the replayed error can be inspected using status.Code.
+type RecordedError struct {
+	Message string     `json:"message"`
+	Code    codes.Code `json:"code,omitempty"`
+}
+
+func (e *RecordedError) Error() string {
+	return e.Message
+}
+
+func (e *RecordedError) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// Cassette contains gateway interactions in the order they were recorded.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// LoadCassette reads the cassette from the file.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to the file.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// interactionKey identifies the interaction by the method and the compacted request.
+func interactionKey(method string, request []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, request); err != nil {
+		return method + " " + string(request)
+	}
+	return method + " " + compacted.String()
+}
+
+type identifierRequest struct {
+	ID string `json:"id"`
+}
+
+type accountRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height"`
+}
+
+type heightRequest struct {
+	Height uint64 `json:"height"`
+}
+
+type scriptRequest struct {
+	Script    string            `json:"script"`
+	Arguments []json.RawMessage `json:"arguments"`
+	Height    uint64            `json:"height,omitempty"`
+	BlockID   string            `json:"blockId,omitempty"`
+}
+
+type resultRequest struct {
+	ID       string `json:"id"`
+	WaitSeal bool   `json:"waitSeal"`
+}
+
+type eventsRequest struct {
+	Type        string `json:"type"`
+	StartHeight uint64 `json:"startHeight"`
+	EndHeight   uint64 `json:"endHeight"`
+}
+
+func newScriptRequest(script []byte, arguments []cadence.Value) (scriptRequest, error) {
+	args := make([]json.RawMessage, len(arguments))
+	for i, arg := range arguments {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return scriptRequest{}, err
+		}
+		args[i] = encoded
+	}
+
+	return scriptRequest{
+		Script:    string(script),
+		Arguments: args,
+	}, nil
+}
+
+// unsignedTransaction removes the signatures from the transaction since signing produces different signatures
+// on every run, so a replayed transaction is matched by its payload only.
+func unsignedTransaction(tx *flow.Transaction) flow.Transaction {
+	unsigned := *tx
+	unsigned.PayloadSignatures = nil
+	unsigned.EnvelopeSignatures = nil
+	return unsigned
+}
+
+var _ Gateway = &RecordingGateway{}
+
+// RecordingGateway is a gateway decorator that records every call to the wrapped gateway in a cassette.
+//
+// The cassette can later be replayed with the ReplayGateway without access to the network.
+type RecordingGateway struct {
+	gateway  Gateway
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecordingGateway wraps the gateway and records its interactions.
+func NewRecordingGateway(gateway Gateway) *RecordingGateway {
+	return &RecordingGateway{
+		gateway:  gateway,
+		cassette: &Cassette{},
+	}
+}
+
+// Cassette returns the interactions recorded so far.
+func (g *RecordingGateway) Cassette() *Cassette {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return &Cassette{
+		Interactions: append([]*Interaction{}, g.cassette.Interactions...),
+	}
+}
+
+// record calls the wrapped gateway and records the request together with the response or error.
+func record[T any](g *RecordingGateway, method string, request any, c codec[T], call func() (T, error)) (T, error) {
+	value, err := call()
+
+	encodedRequest, encodeErr := json.Marshal(request)
+	if encodeErr != nil {
+		return value, err
+	}
+
+	interaction := &Interaction{
+		Method:  method,
+		Request: encodedRequest,
+	}
+
+	if err != nil {
+		interaction.Error = &RecordedError{
+			Message: err.Error(),
+			Code:    status.Code(err),
+		}
+	} else {
+		interaction.Response, encodeErr = c.encode(value)
+		if encodeErr != nil {
+			return value, err
+		}
+	}
+
+	g.mu.Lock()
+	g.cassette.Interactions = append(g.cassette.Interactions, interaction)
+	g.mu.Unlock()
+
+	return value, err
+}
+
+func (g *RecordingGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return record(g, "GetAccount", address, accountCodec, func() (*flow.Account, error) {
+		return g.gateway.GetAccount(ctx, address)
+	})
+}
+
+func (g *RecordingGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return record(g, "GetAccountAtBlockHeight", accountRequest{address.String(), height}, accountCodec,
+		func() (*flow.Account, error) {
+			return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+		},
+	)
+}
+
+func (g *RecordingGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return record(g, "SendSignedTransaction", unsignedTransaction(tx), jsonCodec[*flow.Transaction](),
+		func() (*flow.Transaction, error) {
+			return g.gateway.SendSignedTransaction(ctx, tx)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return record(g, "GetTransaction", identifierRequest{ID.String()}, jsonCodec[*flow.Transaction](),
+		func() (*flow.Transaction, error) {
+			return g.gateway.GetTransaction(ctx, ID)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return record(g, "GetTransactionResultsByBlockID", identifierRequest{blockID.String()}, resultsCodec,
+		func() ([]*flow.TransactionResult, error) {
+			return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return record(g, "GetTransactionResult", resultRequest{ID.String(), waitSeal}, resultCodec,
+		func() (*flow.TransactionResult, error) {
+			return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return record(g, "GetTransactionsByBlockID", identifierRequest{blockID.String()}, jsonCodec[[]*flow.Transaction](),
+		func() ([]*flow.Transaction, error) {
+			return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+		},
+	)
+}
+
+func (g *RecordingGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return record(g, "ExecuteScript", request, valueCodec, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScript(ctx, script, arguments)
+	})
+}
+
+func (g *RecordingGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+	request.Height = height
+
+	return record(g, "ExecuteScriptAtHeight", request, valueCodec, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	})
+}
+
+func (g *RecordingGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+	request.BlockID = ID.String()
+
+	return record(g, "ExecuteScriptAtID", request, valueCodec, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	})
+}
+
+func (g *RecordingGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return record(g, "GetLatestBlock", nil, jsonCodec[*flow.Block](), func() (*flow.Block, error) {
+		return g.gateway.GetLatestBlock(ctx)
+	})
+}
+
+func (g *RecordingGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return record(g, "GetBlockByHeight", heightRequest{height}, jsonCodec[*flow.Block](), func() (*flow.Block, error) {
+		return g.gateway.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (g *RecordingGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return record(g, "GetBlockByID", identifierRequest{ID.String()}, jsonCodec[*flow.Block](), func() (*flow.Block, error) {
+		return g.gateway.GetBlockByID(ctx, ID)
+	})
+}
+
+func (g *RecordingGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return record(g, "GetEvents", eventsRequest{eventType, startHeight, endHeight}, blockEventsCodec,
+		func() ([]flow.BlockEvents, error) {
+			return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return record(g, "GetCollection", identifierRequest{ID.String()}, jsonCodec[*flow.Collection](),
+		func() (*flow.Collection, error) {
+			return g.gateway.GetCollection(ctx, ID)
+		},
+	)
+}
+
+func (g *RecordingGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return record(g, "GetLatestProtocolStateSnapshot", nil, jsonCodec[[]byte](), func() ([]byte, error) {
+		return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+func (g *RecordingGateway) Ping() error {
+	_, err := record(g, "Ping", nil, jsonCodec[any](), func() (any, error) {
+		return nil, g.gateway.Ping()
+	})
+	return err
+}
+
+func (g *RecordingGateway) SecureConnection() bool {
+	secure, _ := record(g, "SecureConnection", nil, jsonCodec[bool](), func() (bool, error) {
+		return g.gateway.SecureConnection(), nil
+	})
+	return secure
+}
+
+var _ Gateway = &ReplayGateway{}
+
+// ReplayGateway is a gateway that serves responses from a recorded cassette without accessing the network.
+//
+// Calls are matched by method and request. When the same call was recorded multiple times the responses are
+// replayed in the recorded order, repeating the last one once they run out.
+type ReplayGateway struct {
+	mu           sync.Mutex
+	interactions map[string][]*Interaction
+	last         map[string]*Interaction
+}
+
+// NewReplayGateway creates a gateway replaying the interactions from the cassette.
+func NewReplayGateway(cassette *Cassette) *ReplayGateway {
+	g := &ReplayGateway{
+		interactions: make(map[string][]*Interaction),
+		last:         make(map[string]*Interaction),
+	}
+
+	for _, interaction := range cassette.Interactions {
+		key := interactionKey(interaction.Method, interaction.Request)
+		g.interactions[key] = append(g.interactions[key], interaction)
+	}
+
+	return g
+}
+
+// next returns the next recorded interaction for the key.
+func (g *ReplayGateway) next(key string) (*Interaction, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	queue := g.interactions[key]
+	if len(queue) == 0 {
+		interaction, ok := g.last[key]
+		return interaction, ok
+	}
+
+	g.interactions[key] = queue[1:]
+	g.last[key] = queue[0]
+	return queue[0], true
+}
+
+// replay returns the recorded response or error for the request.
+func replay[T any](g *ReplayGateway, method string, request any, c codec[T]) (T, error) {
+	var value T
+
+	encodedRequest, err := json.Marshal(request)
+	if err != nil {
+		return value, err
+	}
+
+	interaction, ok := g.next(interactionKey(method, encodedRequest))
+	if !ok {
+		return value, fmt.Errorf("no recorded interaction for %s with request %s", method, encodedRequest)
+	}
+
+	if interaction.Error != nil {
+		return value, interaction.Error
+	}
+
+	return c.decode(interaction.Response)
+}
+
+func (g *ReplayGateway) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
+	return replay(g, "GetAccount", address, accountCodec)
+}
+
+func (g *ReplayGateway) GetAccountAtBlockHeight(_ context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return replay(g, "GetAccountAtBlockHeight", accountRequest{address.String(), height}, accountCodec)
+}
+
+func (g *ReplayGateway) SendSignedTransaction(_ context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return replay(g, "SendSignedTransaction", unsignedTransaction(tx), jsonCodec[*flow.Transaction]())
+}
+
+func (g *ReplayGateway) GetTransaction(_ context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return replay(g, "GetTransaction", identifierRequest{ID.String()}, jsonCodec[*flow.Transaction]())
+}
+
+func (g *ReplayGateway) GetTransactionResultsByBlockID(_ context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return replay(g, "GetTransactionResultsByBlockID", identifierRequest{blockID.String()}, resultsCodec)
+}
+
+func (g *ReplayGateway) GetTransactionResult(_ context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return replay(g, "GetTransactionResult", resultRequest{ID.String(), waitSeal}, resultCodec)
+}
+
+func (g *ReplayGateway) GetTransactionsByBlockID(_ context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return replay(g, "GetTransactionsByBlockID", identifierRequest{blockID.String()}, jsonCodec[[]*flow.Transaction]())
+}
+
+func (g *ReplayGateway) ExecuteScript(_ context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return replay(g, "ExecuteScript", request, valueCodec)
+}
+
+func (g *ReplayGateway) ExecuteScriptAtHeight(_ context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+	request.Height = height
+
+	return replay(g, "ExecuteScriptAtHeight", request, valueCodec)
+}
+
+func (g *ReplayGateway) ExecuteScriptAtID(_ context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	request, err := newScriptRequest(script, arguments)
+	if err != nil {
+		return nil, err
+	}
+	request.BlockID = ID.String()
+
+	return replay(g, "ExecuteScriptAtID", request, valueCodec)
+}
+
+func (g *ReplayGateway) GetLatestBlock(_ context.Context) (*flow.Block, error) {
+	return replay(g, "GetLatestBlock", nil, jsonCodec[*flow.Block]())
+}
+
+func (g *ReplayGateway) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	return replay(g, "GetBlockByHeight", heightRequest{height}, jsonCodec[*flow.Block]())
+}
+
+func (g *ReplayGateway) GetBlockByID(_ context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return replay(g, "GetBlockByID", identifierRequest{ID.String()}, jsonCodec[*flow.Block]())
+}
+
+func (g *ReplayGateway) GetEvents(_ context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return replay(g, "GetEvents", eventsRequest{eventType, startHeight, endHeight}, blockEventsCodec)
+}
+
+func (g *ReplayGateway) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return replay(g, "GetCollection", identifierRequest{ID.String()}, jsonCodec[*flow.Collection]())
+}
+
+func (g *ReplayGateway) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
+	return replay(g, "GetLatestProtocolStateSnapshot", nil, jsonCodec[[]byte]())
+}
+
+func (g *ReplayGateway) Ping() error {
+	_, err := replay(g, "Ping", nil, jsonCodec[any]())
+	return err
+}
+
+func (g *ReplayGateway) SecureConnection() bool {
+	secure, _ := replay(g, "SecureConnection", nil, jsonCodec[bool]())
+	return secure
+}
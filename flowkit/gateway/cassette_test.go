@@ -0,0 +1,108 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func TestCassette(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("0x01")
+	script := []byte("pub fun main(a: Int): Int { return a }")
+	args := []cadence.Value{cadence.NewInt(1)}
+
+	// record a session against the mocked gateway and save it to the cassette
+	gw := mocks.DefaultMockGateway()
+	gw.ExecuteScript.Run(func(args mock.Arguments) {
+		gw.ExecuteScript.Return(cadence.NewInt(1), nil)
+	})
+	gw.GetEvents.Return(nil, status.Error(codes.NotFound, "events not found"))
+
+	recorder := gateway.NewRecordingGateway(gw.Mock)
+	account, err := recorder.GetAccount(ctx, address)
+	require.NoError(t, err)
+	value, err := recorder.ExecuteScript(ctx, script, args)
+	require.NoError(t, err)
+	_, err = recorder.GetEvents(ctx, "flow.AccountCreated", 0, 10)
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, recorder.Cassette().Save(path))
+
+	cassette, err := gateway.LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 3)
+	replayer := gateway.NewReplayGateway(cassette)
+
+	t.Run("Replay responses", func(t *testing.T) {
+		replayed, err := replayer.GetAccount(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, account.Address, replayed.Address)
+		assert.Equal(t, account.Keys[0].PublicKey.String(), replayed.Keys[0].PublicKey.String())
+
+		replayedValue, err := replayer.ExecuteScript(ctx, script, args)
+		require.NoError(t, err)
+		assert.Equal(t, value.String(), replayedValue.String())
+	})
+
+	t.Run("Replay errors", func(t *testing.T) {
+		_, err := replayer.GetEvents(ctx, "flow.AccountCreated", 0, 10)
+		assert.EqualError(t, err, "rpc error: code = NotFound desc = events not found")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Fail unrecorded requests", func(t *testing.T) {
+		_, err := replayer.ExecuteScript(ctx, script, []cadence.Value{cadence.NewInt(2)})
+		assert.ErrorContains(t, err, "no recorded interaction for ExecuteScript")
+	})
+
+	t.Run("Replay repeated calls in order", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		first, second := tests.NewBlock(), tests.NewBlock()
+		first.Height, second.Height = 1, 2
+		gw.GetLatestBlock.Return(first, nil).Once()
+		gw.Mock.On(mocks.GetLatestBlockFunc, mock.Anything).Return(second, nil)
+
+		recorder := gateway.NewRecordingGateway(gw.Mock)
+		_, _ = recorder.GetLatestBlock(ctx)
+		_, _ = recorder.GetLatestBlock(ctx)
+
+		replayer := gateway.NewReplayGateway(recorder.Cassette())
+		for _, expected := range []*flow.Block{first, second, second} {
+			block, err := replayer.GetLatestBlock(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Height, block.Height)
+		}
+	})
+}
@@ -0,0 +1,349 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// codec converts values to and from their JSON representation.
+type codec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+func jsonCodec[T any]() codec[T] {
+	return codec[T]{
+		encode: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		decode: func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+}
+
+// jsonEvent is the JSON representation of an event, the cadence value is decoded from the payload.
+type jsonEvent struct {
+	Type             string
+	TransactionID    flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+	Payload          []byte
+}
+
+func toJSONEvents(events []flow.Event) ([]jsonEvent, error) {
+	encoded := make([]jsonEvent, len(events))
+	for i, event := range events {
+		payload := event.Payload
+		if len(payload) == 0 {
+			var err error
+			payload, err = jsoncdc.Encode(event.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		encoded[i] = jsonEvent{
+			Type:             event.Type,
+			TransactionID:    event.TransactionID,
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          payload,
+		}
+	}
+	return encoded, nil
+}
+
+func fromJSONEvents(encoded []jsonEvent) ([]flow.Event, error) {
+	events := make([]flow.Event, len(encoded))
+	for i, event := range encoded {
+		value, err := jsoncdc.Decode(nil, event.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		eventValue, ok := value.(cadence.Event)
+		if !ok {
+			return nil, fmt.Errorf("value is not an event: %s", event.Type)
+		}
+
+		events[i] = flow.Event{
+			Type:             event.Type,
+			TransactionID:    event.TransactionID,
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Value:            eventValue,
+			Payload:          event.Payload,
+		}
+	}
+	return events, nil
+}
+
+// jsonResult is the JSON representation of a transaction result.
+type jsonResult struct {
+	Status        flow.TransactionStatus
+	Error         string
+	Events        []jsonEvent
+	BlockID       flow.Identifier
+	BlockHeight   uint64
+	TransactionID flow.Identifier
+}
+
+func toJSONResult(result *flow.TransactionResult) (*jsonResult, error) {
+	events, err := toJSONEvents(result.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := &jsonResult{
+		Status:        result.Status,
+		Events:        events,
+		BlockID:       result.BlockID,
+		BlockHeight:   result.BlockHeight,
+		TransactionID: result.TransactionID,
+	}
+	if result.Error != nil {
+		encoded.Error = result.Error.Error()
+	}
+
+	return encoded, nil
+}
+
+func fromJSONResult(encoded *jsonResult) (*flow.TransactionResult, error) {
+	events, err := fromJSONEvents(encoded.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &flow.TransactionResult{
+		Status:        encoded.Status,
+		Events:        events,
+		BlockID:       encoded.BlockID,
+		BlockHeight:   encoded.BlockHeight,
+		TransactionID: encoded.TransactionID,
+	}
+	if encoded.Error != "" {
+		result.Error = errors.New(encoded.Error)
+	}
+
+	return result, nil
+}
+
+var resultCodec = codec[*flow.TransactionResult]{
+	encode: func(result *flow.TransactionResult) ([]byte, error) {
+		if result == nil {
+			return json.Marshal(nil)
+		}
+
+		encoded, err := toJSONResult(result)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(encoded)
+	},
+	decode: func(data []byte) (*flow.TransactionResult, error) {
+		var encoded *jsonResult
+		if err := json.Unmarshal(data, &encoded); err != nil || encoded == nil {
+			return nil, err
+		}
+		return fromJSONResult(encoded)
+	},
+}
+
+var resultsCodec = codec[[]*flow.TransactionResult]{
+	encode: func(results []*flow.TransactionResult) ([]byte, error) {
+		encoded := make([]*jsonResult, len(results))
+		for i, result := range results {
+			var err error
+			encoded[i], err = toJSONResult(result)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(encoded)
+	},
+	decode: func(data []byte) ([]*flow.TransactionResult, error) {
+		var encoded []*jsonResult
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return nil, err
+		}
+
+		results := make([]*flow.TransactionResult, len(encoded))
+		for i, c := range encoded {
+			var err error
+			results[i], err = fromJSONResult(c)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	},
+}
+
+// jsonBlockEvents is the JSON representation of block events.
+type jsonBlockEvents struct {
+	BlockID        flow.Identifier
+	Height         uint64
+	BlockTimestamp time.Time
+	Events         []jsonEvent
+}
+
+var blockEventsCodec = codec[[]flow.BlockEvents]{
+	encode: func(blockEvents []flow.BlockEvents) ([]byte, error) {
+		encoded := make([]jsonBlockEvents, len(blockEvents))
+		for i, block := range blockEvents {
+			events, err := toJSONEvents(block.Events)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded[i] = jsonBlockEvents{
+				BlockID:        block.BlockID,
+				Height:         block.Height,
+				BlockTimestamp: block.BlockTimestamp,
+				Events:         events,
+			}
+		}
+		return json.Marshal(encoded)
+	},
+	decode: func(data []byte) ([]flow.BlockEvents, error) {
+		var encoded []jsonBlockEvents
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return nil, err
+		}
+
+		blockEvents := make([]flow.BlockEvents, len(encoded))
+		for i, block := range encoded {
+			events, err := fromJSONEvents(block.Events)
+			if err != nil {
+				return nil, err
+			}
+
+			blockEvents[i] = flow.BlockEvents{
+				BlockID:        block.BlockID,
+				Height:         block.Height,
+				BlockTimestamp: block.BlockTimestamp,
+				Events:         events,
+			}
+		}
+		return blockEvents, nil
+	},
+}
+
+// jsonAccountKey is the JSON representation of an account key, the public key is stored encoded.
+type jsonAccountKey struct {
+	Index          int
+	PublicKey      []byte
+	SigAlgo        crypto.SignatureAlgorithm
+	HashAlgo       crypto.HashAlgorithm
+	Weight         int
+	SequenceNumber uint64
+	Revoked        bool
+}
+
+// jsonAccount is the JSON representation of an account.
+type jsonAccount struct {
+	Address   flow.Address
+	Balance   uint64
+	Code      []byte
+	Keys      []jsonAccountKey
+	Contracts map[string][]byte
+}
+
+var accountCodec = codec[*flow.Account]{
+	encode: func(account *flow.Account) ([]byte, error) {
+		if account == nil {
+			return json.Marshal(nil)
+		}
+
+		keys := make([]jsonAccountKey, len(account.Keys))
+		for i, key := range account.Keys {
+			keys[i] = jsonAccountKey{
+				Index:          key.Index,
+				PublicKey:      key.PublicKey.Encode(),
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			}
+		}
+
+		return json.Marshal(jsonAccount{
+			Address:   account.Address,
+			Balance:   account.Balance,
+			Code:      account.Code,
+			Keys:      keys,
+			Contracts: account.Contracts,
+		})
+	},
+	decode: func(data []byte) (*flow.Account, error) {
+		var encoded *jsonAccount
+		if err := json.Unmarshal(data, &encoded); err != nil || encoded == nil {
+			return nil, err
+		}
+
+		keys := make([]*flow.AccountKey, len(encoded.Keys))
+		for i, key := range encoded.Keys {
+			publicKey, err := crypto.DecodePublicKey(key.SigAlgo, key.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			keys[i] = &flow.AccountKey{
+				Index:          key.Index,
+				PublicKey:      publicKey,
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			}
+		}
+
+		return &flow.Account{
+			Address:   encoded.Address,
+			Balance:   encoded.Balance,
+			Code:      encoded.Code,
+			Keys:      keys,
+			Contracts: encoded.Contracts,
+		}, nil
+	},
+}
+
+// valueCodec encodes cadence values using the JSON-Cadence format.
+var valueCodec = codec[cadence.Value]{
+	encode: func(value cadence.Value) ([]byte, error) {
+		return jsoncdc.Encode(value)
+	},
+	decode: func(data []byte) (cadence.Value, error) {
+		return jsoncdc.Decode(nil, data)
+	},
+}
@@ -0,0 +1,272 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// endpointDownPeriod is how long an endpoint that is down is only used as a last resort.
+const endpointDownPeriod = 30 * time.Second
+
+var _ Gateway = &FailoverGateway{}
+
+// FailoverGateway is a gateway that distributes calls between multiple access node endpoints of the same network.
+//
+// Endpoints are selected using the network endpoint policy. When an endpoint returns a transient error it is
+// marked as down and the call is repeated on the next endpoint. Endpoints that were down must respond to Ping
+// before they are used again, and are only tried first again after some time passes.
+type FailoverGateway struct {
+	gateways []Gateway
+	policy   config.EndpointPolicy
+	mu       sync.Mutex
+	down     []time.Time
+	next     int
+}
+
+// NewFailoverGateway creates a gateway using the endpoint gateways according to the policy,
+// the failover policy is used if none is provided.
+func NewFailoverGateway(gateways []Gateway, policy config.EndpointPolicy) (*FailoverGateway, error) {
+	if len(gateways) == 0 {
+		return nil, fmt.Errorf("at least one endpoint gateway must be provided")
+	}
+	if policy == "" {
+		policy = config.FailoverPolicy
+	}
+	if policy != config.FailoverPolicy && policy != config.RoundRobinPolicy {
+		return nil, fmt.Errorf("unsupported endpoint policy %s", policy)
+	}
+
+	return &FailoverGateway{
+		gateways: gateways,
+		policy:   policy,
+		down:     make([]time.Time, len(gateways)),
+	}, nil
+}
+
+// endpoints returns the order in which the endpoints are tried,
+// endpoints that recently went down are moved to the end.
+func (g *FailoverGateway) endpoints() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	start := 0
+	if g.policy == config.RoundRobinPolicy {
+		start = g.next
+		g.next = (g.next + 1) % len(g.gateways)
+	}
+
+	order := make([]int, 0, len(g.gateways))
+	recentlyDown := make([]int, 0)
+	for i := range g.gateways {
+		index := (start + i) % len(g.gateways)
+		if !g.down[index].IsZero() && time.Since(g.down[index]) < endpointDownPeriod {
+			recentlyDown = append(recentlyDown, index)
+		} else {
+			order = append(order, index)
+		}
+	}
+
+	return append(order, recentlyDown...)
+}
+
+func (g *FailoverGateway) isDown(index int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.down[index].IsZero()
+}
+
+func (g *FailoverGateway) setDown(index int, down bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if down {
+		g.down[index] = time.Now()
+	} else {
+		g.down[index] = time.Time{}
+	}
+}
+
+// isUnavailable checks whether the access node couldn't be reached, in which case the request wasn't processed.
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// withFailover calls the endpoints in order until the call succeeds or returns an error that shouldn't fail over.
+func withFailover[T any](
+	ctx context.Context,
+	g *FailoverGateway,
+	failover func(error) bool,
+	call func(Gateway) (T, error),
+) (T, error) {
+	var result T
+	var err error
+
+	for _, index := range g.endpoints() {
+		gw := g.gateways[index]
+
+		if g.isDown(index) {
+			if err = gw.Ping(); err != nil {
+				g.setDown(index, true)
+				continue
+			}
+		}
+
+		result, err = call(gw)
+		if err == nil || !failover(err) || ctx.Err() != nil {
+			g.setDown(index, false)
+			return result, err
+		}
+
+		g.setDown(index, true)
+	}
+
+	return result, err
+}
+
+func (g *FailoverGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Account, error) {
+		return gw.GetAccount(ctx, address)
+	})
+}
+
+func (g *FailoverGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Account, error) {
+		return gw.GetAccountAtBlockHeight(ctx, address, height)
+	})
+}
+
+// SendSignedTransaction only fails over when the endpoint is unavailable, since otherwise the transaction might have been submitted.
+func (g *FailoverGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return withFailover(ctx, g, isUnavailable, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.SendSignedTransaction(ctx, tx)
+	})
+}
+
+func (g *FailoverGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.GetTransaction(ctx, ID)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) ([]*flow.TransactionResult, error) {
+		return gw.GetTransactionResultsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.TransactionResult, error) {
+		return gw.GetTransactionResult(ctx, ID, waitSeal)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) ([]*flow.Transaction, error) {
+		return gw.GetTransactionsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScript(ctx, script, arguments)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtID(ctx, script, arguments, ID)
+	})
+}
+
+func (g *FailoverGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetLatestBlock(ctx)
+	})
+}
+
+func (g *FailoverGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (g *FailoverGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByID(ctx, ID)
+	})
+}
+
+func (g *FailoverGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) ([]flow.BlockEvents, error) {
+		return gw.GetEvents(ctx, eventType, startHeight, endHeight)
+	})
+}
+
+func (g *FailoverGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) (*flow.Collection, error) {
+		return gw.GetCollection(ctx, ID)
+	})
+}
+
+func (g *FailoverGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return withFailover(ctx, g, isRetryable, func(gw Gateway) ([]byte, error) {
+		return gw.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+// Ping succeeds if any of the endpoints is available.
+func (g *FailoverGateway) Ping() error {
+	var err error
+	for _, index := range g.endpoints() {
+		if err = g.gateways[index].Ping(); err == nil {
+			g.setDown(index, false)
+			return nil
+		}
+		g.setDown(index, true)
+	}
+
+	return err
+}
+
+// SecureConnection returns true only if all the endpoints use secure connections.
+func (g *FailoverGateway) SecureConnection() bool {
+	for _, gw := range g.gateways {
+		if !gw.SecureConnection() {
+			return false
+		}
+	}
+	return true
+}
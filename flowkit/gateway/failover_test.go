@@ -0,0 +1,120 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func TestFailoverGateway(t *testing.T) {
+	ctx := context.Background()
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	t.Run("Fail over to the next endpoint", func(t *testing.T) {
+		primary, secondary := mocks.DefaultMockGateway(), mocks.DefaultMockGateway()
+		primary.GetLatestBlock.Return(nil, unavailable)
+
+		gw, err := gateway.NewFailoverGateway([]gateway.Gateway{primary.Mock, secondary.Mock}, config.FailoverPolicy)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			block, err := gw.GetLatestBlock(ctx)
+			require.NoError(t, err)
+			assert.NotNil(t, block)
+		}
+
+		// the primary endpoint is skipped while it's down
+		primary.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 1)
+		primary.Mock.AssertNumberOfCalls(t, "Ping", 0)
+		secondary.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 2)
+	})
+
+	t.Run("Don't fail over other errors", func(t *testing.T) {
+		primary, secondary := mocks.DefaultMockGateway(), mocks.DefaultMockGateway()
+		primary.GetEvents.Return(nil, status.Error(codes.InvalidArgument, "invalid range"))
+
+		gw, err := gateway.NewFailoverGateway([]gateway.Gateway{primary.Mock, secondary.Mock}, config.FailoverPolicy)
+		require.NoError(t, err)
+
+		_, err = gw.GetEvents(ctx, "flow.AccountCreated", 10, 0)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		secondary.Mock.AssertNotCalled(t, mocks.GetEventsFunc, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Only fail over sending transactions if unavailable", func(t *testing.T) {
+		primary, secondary := mocks.DefaultMockGateway(), mocks.DefaultMockGateway()
+		primary.SendSignedTransaction.Run(func(args mock.Arguments) {
+			primary.SendSignedTransaction.Return(nil, status.Error(codes.DeadlineExceeded, "timeout"))
+		})
+
+		gw, err := gateway.NewFailoverGateway([]gateway.Gateway{primary.Mock, secondary.Mock}, config.FailoverPolicy)
+		require.NoError(t, err)
+
+		_, err = gw.SendSignedTransaction(ctx, tests.NewTransaction())
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		secondary.Mock.AssertNotCalled(t, mocks.SendSignedTransactionFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Distribute calls with round robin", func(t *testing.T) {
+		first, second := mocks.DefaultMockGateway(), mocks.DefaultMockGateway()
+
+		gw, err := gateway.NewFailoverGateway([]gateway.Gateway{first.Mock, second.Mock}, config.RoundRobinPolicy)
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			_, err := gw.GetCollection(ctx, flow.HexToID("01"))
+			require.NoError(t, err)
+		}
+
+		first.Mock.AssertNumberOfCalls(t, mocks.GetCollectionFunc, 2)
+		second.Mock.AssertNumberOfCalls(t, mocks.GetCollectionFunc, 2)
+	})
+
+	t.Run("Return error if all endpoints are down", func(t *testing.T) {
+		primary, secondary := mocks.DefaultMockGateway(), mocks.DefaultMockGateway()
+		primary.GetLatestBlock.Return(nil, unavailable)
+		secondary.GetLatestBlock.Return(nil, unavailable)
+		primary.Mock.On("Ping").Return(unavailable)
+		secondary.Mock.On("Ping").Return(unavailable)
+
+		gw, err := gateway.NewFailoverGateway([]gateway.Gateway{primary.Mock, secondary.Mock}, "")
+		require.NoError(t, err)
+
+		_, err = gw.GetLatestBlock(ctx)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		// endpoints that are down must respond to ping before they are used again
+		_, err = gw.GetLatestBlock(ctx)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		primary.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 1)
+	})
+}
@@ -22,13 +22,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	grpcAccess "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go/utils/grpcutils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/onflow/flow-cli/flowkit/config"
@@ -40,28 +40,33 @@ const maxGRPCMessageSize = 1024 * 1024 * 60
 // GrpcGateway is a gateway implementation that uses the Flow Access gRPC API.
 type GrpcGateway struct {
 	client       *grpcAccess.Client
-	ctx          context.Context
 	secureClient bool
 }
 
 // NewGrpcGateway returns a new gRPC gateway.
+//
+// The connection uses TLS if the network TLS configuration is provided, otherwise it is insecure.
 func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
+	transportCredentials := insecure.NewCredentials()
+	if network.TLS.IsEnabled() {
+		tlsConfig, err := NewTLSConfig(*network.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS configuration for host %s: %w", network.Host, err)
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
 
 	gClient, err := grpcAccess.NewClient(
 		network.Host,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
+		dialOptions(network, grpc.WithTransportCredentials(transportCredentials))...,
 	)
-	ctx := context.Background()
-
 	if err != nil || gClient == nil {
 		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
 	}
 
 	return &GrpcGateway{
 		client:       gClient,
-		ctx:          ctx,
-		secureClient: false,
+		secureClient: network.TLS.IsEnabled(),
 	}, nil
 }
 
@@ -72,27 +77,33 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", network.Key, err)
 	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		secureDialOpts,
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
-	ctx := context.Background()
-
+	gClient, err := grpcAccess.NewClient(network.Host, dialOptions(network, secureDialOpts)...)
 	if err != nil || gClient == nil {
 		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
 	}
 
 	return &GrpcGateway{
 		client:       gClient,
-		ctx:          ctx,
 		secureClient: true,
 	}, nil
 }
 
+// dialOptions returns the options used to connect to the network access node using the transport security option.
+func dialOptions(network config.Network, transport grpc.DialOption) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		transport,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
+	}
+	if len(network.Headers) > 0 {
+		opts = append(opts, WithHeaders(network.HeaderValues()))
+	}
+
+	return opts
+}
+
 // GetAccount gets an account by address from the Flow Access API.
-func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
-	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
+func (g *GrpcGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtLatestBlock(ctx, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account with address %s: %w", address, err)
 	}
@@ -100,9 +111,19 @@ func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
 	return account, nil
 }
 
+// GetAccountAtBlockHeight gets an account by address as it was at the block height from the Flow Access API.
+func (g *GrpcGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s at height %d: %w", address, height, err)
+	}
+
+	return account, nil
+}
+
 // SendSignedTransaction sends a transaction to flow that is already prepared and signed.
-func (g *GrpcGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
-	err := g.client.SendTransaction(g.ctx, *tx)
+func (g *GrpcGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	err := g.client.SendTransaction(ctx, *tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
@@ -111,72 +132,67 @@ func (g *GrpcGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transac
 }
 
 // GetTransaction gets a transaction by ID from the Flow Access API.
-func (g *GrpcGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
-	return g.client.GetTransaction(g.ctx, ID)
+func (g *GrpcGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return g.client.GetTransaction(ctx, ID)
 }
 
-func (g *GrpcGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
-	return g.client.GetTransactionResultsByBlockID(g.ctx, blockID)
+func (g *GrpcGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return g.client.GetTransactionResultsByBlockID(ctx, blockID)
 }
 
-func (g *GrpcGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
-	return g.client.GetTransactionsByBlockID(g.ctx, blockID)
+func (g *GrpcGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return g.client.GetTransactionsByBlockID(ctx, blockID)
 }
 
-// GetTransactionResult gets a transaction result by ID from the Flow Access API.
-func (g *GrpcGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
-	result, err := g.client.GetTransactionResult(g.ctx, ID)
-	if err != nil {
-		return nil, err
-	}
-
-	if result.Status != flow.TransactionStatusSealed && waitSeal {
-		time.Sleep(time.Second)
-		return g.GetTransactionResult(ID, waitSeal)
+// GetTransactionResult gets a transaction result by ID from the Flow Access API, waiting for it to be sealed if waitSeal is set.
+func (g *GrpcGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	if waitSeal {
+		return WaitForTransactionResult(ctx, g, ID, flow.TransactionStatusSealed, DefaultPollInterval)
 	}
 
-	return result, nil
+	return g.client.GetTransactionResult(ctx, ID)
 }
 
 // ExecuteScript executes a script on Flow through the Access API.
-func (g *GrpcGateway) ExecuteScript(script []byte, arguments []cadence.Value) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtLatestBlock(g.ctx, script, arguments)
+func (g *GrpcGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
 }
 
 // ExecuteScriptAtHeight executes a script at block height.
-func (g *GrpcGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+func (g *GrpcGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
 }
 
 // ExecuteScriptAtID executes a script at block ID.
-func (g *GrpcGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockID(g.ctx, ID, script, arguments)
+func (g *GrpcGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtBlockID(ctx, ID, script, arguments)
 }
 
 // GetLatestBlock gets the latest block on Flow through the Access API.
-func (g *GrpcGateway) GetLatestBlock() (*flow.Block, error) {
-	return g.client.GetLatestBlock(g.ctx, true)
+func (g *GrpcGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return g.client.GetLatestBlock(ctx, true)
 }
 
 // GetBlockByID get block by ID from the Flow Access API.
-func (g *GrpcGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
-	return g.client.GetBlockByID(g.ctx, id)
+func (g *GrpcGateway) GetBlockByID(ctx context.Context, id flow.Identifier) (*flow.Block, error) {
+	return g.client.GetBlockByID(ctx, id)
 }
 
 // GetBlockByHeight get block by height from the Flow Access API.
-func (g *GrpcGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
-	return g.client.GetBlockByHeight(g.ctx, height)
+func (g *GrpcGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return g.client.GetBlockByHeight(ctx, height)
 }
 
 // GetEvents gets events by name and block range from the Flow Access API.
 func (g *GrpcGateway) GetEvents(
+	ctx context.Context,
 	eventType string,
 	startHeight uint64,
 	endHeight uint64,
 ) ([]flow.BlockEvents, error) {
 
 	events, err := g.client.GetEventsForHeightRange(
-		g.ctx,
+		ctx,
 		eventType,
 		startHeight,
 		endHeight,
@@ -186,18 +202,18 @@ func (g *GrpcGateway) GetEvents(
 }
 
 // GetCollection gets a collection by ID from the Flow Access API.
-func (g *GrpcGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
-	return g.client.GetCollection(g.ctx, id)
+func (g *GrpcGateway) GetCollection(ctx context.Context, id flow.Identifier) (*flow.Collection, error) {
+	return g.client.GetCollection(ctx, id)
 }
 
 // GetLatestProtocolStateSnapshot gets the latest finalized protocol state snapshot
-func (g *GrpcGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
-	return g.client.GetLatestProtocolStateSnapshot(g.ctx)
+func (g *GrpcGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return g.client.GetLatestProtocolStateSnapshot(ctx)
 }
 
 // Ping is used to check if the access node is alive and healthy.
 func (g *GrpcGateway) Ping() error {
-	return g.client.Ping(g.ctx)
+	return g.client.Ping(context.Background())
 }
 
 // SecureConnection is used to log warning if a service should be using a secure client but is not
@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var _ credentials.PerRPCCredentials = headerCredentials{}
+
+// headerCredentials attaches the headers as metadata to every gRPC call.
+type headerCredentials map[string]string
+
+func (h headerCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return h, nil
+}
+
+// RequireTransportSecurity returns false so headers can also be used with insecure connections,
+// for example when TLS is terminated by a local proxy.
+func (h headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithHeaders returns a dial option which attaches the headers as metadata to every gRPC call,
+// header names are converted to lowercase as required by gRPC.
+func WithHeaders(headers map[string]string) grpc.DialOption {
+	metadata := make(headerCredentials, len(headers))
+	for name, value := range headers {
+		metadata[strings.ToLower(name)] = value
+	}
+
+	return grpc.WithPerRPCCredentials(metadata)
+}
+
+var _ http.RoundTripper = &headerTransport{}
+
+// headerTransport is an HTTP transport which attaches the headers to every request.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request must not be modified by the transport
+	req = req.Clone(req.Context())
+	for name, value := range h.headers {
+		req.Header.Set(name, value)
+	}
+
+	return h.base.RoundTrip(req)
+}
+
+// newHeaderClient returns an HTTP client, separate from the default client, which attaches the headers to every request.
+func newHeaderClient(headers map[string]string) *http.Client {
+	return &http.Client{
+		Transport: &headerTransport{base: http.DefaultTransport, headers: headers},
+	}
+}
+
+// setRestHTTPClient sets the HTTP client used by the SDK REST API client.
+//
+// The SDK client always uses the default HTTP client and provides no option to change it, so the client of its
+// request handler is replaced. An error is returned if the SDK client doesn't have the expected structure.
+func setRestHTTPClient(client *httpAccess.Client, httpClient *http.Client) error {
+	errUnsupported := fmt.Errorf("the REST API client doesn't support setting the HTTP client")
+
+	base := reflect.ValueOf(client).Elem().FieldByName("httpClient")
+	if base.Kind() != reflect.Pointer || base.IsNil() {
+		return errUnsupported
+	}
+
+	handler := base.Elem().FieldByName("handler")
+	if handler.Kind() != reflect.Interface || handler.IsNil() || handler.Elem().Kind() != reflect.Pointer {
+		return errUnsupported
+	}
+
+	field := handler.Elem().Elem().FieldByName("client")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(httpClient) {
+		return errUnsupported
+	}
+
+	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Set(reflect.ValueOf(httpClient))
+	return nil
+}
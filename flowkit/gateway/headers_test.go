@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+type pingServer struct {
+	access.UnimplementedAccessAPIServer
+	metadata chan metadata.MD
+}
+
+func (s *pingServer) Ping(ctx context.Context, _ *access.PingRequest) (*access.PingResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.metadata <- md
+	return &access.PingResponse{}, nil
+}
+
+func TestWithHeaders(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &pingServer{metadata: make(chan metadata.MD, 1)}
+	server := grpc.NewServer()
+	access.RegisterAccessAPIServer(server, srv)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		gateway.WithHeaders(map[string]string{"X-Api-Key": "secret", "x-client": "flow-cli"}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = access.NewAccessAPIClient(conn).Ping(context.Background(), &access.PingRequest{})
+	require.NoError(t, err)
+
+	md := <-srv.metadata
+	assert.Equal(t, []string{"secret"}, md.Get("x-api-key"))
+	assert.Equal(t, []string{"flow-cli"}, md.Get("x-client"))
+}
@@ -0,0 +1,188 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Interceptor is called around every call of an instrumented gateway with the gateway method name.
+//
+// The interceptor must call invoke to perform the call, passing the context used for the call,
+// and return the error returned by invoke, or its own error to abort the call.
+type Interceptor func(ctx context.Context, method string, invoke func(context.Context) error) error
+
+// chainInterceptors combines the interceptors so the first one is called first and wraps all the others.
+func chainInterceptors(interceptors []Interceptor) Interceptor {
+	return func(ctx context.Context, method string, invoke func(context.Context) error) error {
+		next := invoke
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context) error {
+				return interceptor(ctx, method, inner)
+			}
+		}
+
+		return next(ctx)
+	}
+}
+
+var _ Gateway = &InstrumentedGateway{}
+
+// InstrumentedGateway is a gateway decorator that calls the interceptors around every call to the wrapped gateway,
+// which can be used to log, measure or trace the calls.
+type InstrumentedGateway struct {
+	gateway     Gateway
+	interceptor Interceptor
+}
+
+// NewInstrumentedGateway wraps the gateway with the interceptors, which are called in the order provided.
+func NewInstrumentedGateway(gateway Gateway, interceptors ...Interceptor) *InstrumentedGateway {
+	return &InstrumentedGateway{
+		gateway:     gateway,
+		interceptor: chainInterceptors(interceptors),
+	}
+}
+
+// intercept calls the function through the interceptors.
+func intercept[T any](
+	ctx context.Context,
+	g *InstrumentedGateway,
+	method string,
+	call func(context.Context) (T, error),
+) (T, error) {
+	var result T
+	err := g.interceptor(ctx, method, func(ctx context.Context) error {
+		var err error
+		result, err = call(ctx)
+		return err
+	})
+
+	return result, err
+}
+
+func (g *InstrumentedGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return intercept(ctx, g, "GetAccount", func(ctx context.Context) (*flow.Account, error) {
+		return g.gateway.GetAccount(ctx, address)
+	})
+}
+
+func (g *InstrumentedGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return intercept(ctx, g, "GetAccountAtBlockHeight", func(ctx context.Context) (*flow.Account, error) {
+		return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+	})
+}
+
+func (g *InstrumentedGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return intercept(ctx, g, "SendSignedTransaction", func(ctx context.Context) (*flow.Transaction, error) {
+		return g.gateway.SendSignedTransaction(ctx, tx)
+	})
+}
+
+func (g *InstrumentedGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return intercept(ctx, g, "GetTransaction", func(ctx context.Context) (*flow.Transaction, error) {
+		return g.gateway.GetTransaction(ctx, ID)
+	})
+}
+
+func (g *InstrumentedGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return intercept(ctx, g, "GetTransactionResultsByBlockID", func(ctx context.Context) ([]*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *InstrumentedGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return intercept(ctx, g, "GetTransactionResult", func(ctx context.Context) (*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+	})
+}
+
+func (g *InstrumentedGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return intercept(ctx, g, "GetTransactionsByBlockID", func(ctx context.Context) ([]*flow.Transaction, error) {
+		return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *InstrumentedGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return intercept(ctx, g, "ExecuteScript", func(ctx context.Context) (cadence.Value, error) {
+		return g.gateway.ExecuteScript(ctx, script, arguments)
+	})
+}
+
+func (g *InstrumentedGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return intercept(ctx, g, "ExecuteScriptAtHeight", func(ctx context.Context) (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	})
+}
+
+func (g *InstrumentedGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return intercept(ctx, g, "ExecuteScriptAtID", func(ctx context.Context) (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	})
+}
+
+func (g *InstrumentedGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return intercept(ctx, g, "GetLatestBlock", func(ctx context.Context) (*flow.Block, error) {
+		return g.gateway.GetLatestBlock(ctx)
+	})
+}
+
+func (g *InstrumentedGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return intercept(ctx, g, "GetBlockByHeight", func(ctx context.Context) (*flow.Block, error) {
+		return g.gateway.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (g *InstrumentedGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return intercept(ctx, g, "GetBlockByID", func(ctx context.Context) (*flow.Block, error) {
+		return g.gateway.GetBlockByID(ctx, ID)
+	})
+}
+
+func (g *InstrumentedGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return intercept(ctx, g, "GetEvents", func(ctx context.Context) ([]flow.BlockEvents, error) {
+		return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+	})
+}
+
+func (g *InstrumentedGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return intercept(ctx, g, "GetCollection", func(ctx context.Context) (*flow.Collection, error) {
+		return g.gateway.GetCollection(ctx, ID)
+	})
+}
+
+func (g *InstrumentedGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return intercept(ctx, g, "GetLatestProtocolStateSnapshot", func(ctx context.Context) ([]byte, error) {
+		return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+// Ping is intercepted using a background context since the gateway doesn't accept one.
+func (g *InstrumentedGateway) Ping() error {
+	return g.interceptor(context.Background(), "Ping", func(context.Context) error {
+		return g.gateway.Ping()
+	})
+}
+
+func (g *InstrumentedGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
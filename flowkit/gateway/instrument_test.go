@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+type contextKey struct{}
+
+func TestInstrumentedGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Call interceptors in order", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+
+		calls := make([]string, 0)
+		record := func(name string) gateway.Interceptor {
+			return func(ctx context.Context, method string, invoke func(context.Context) error) error {
+				calls = append(calls, name+" "+method)
+				return invoke(context.WithValue(ctx, contextKey{}, name))
+			}
+		}
+
+		instrumented := gateway.NewInstrumentedGateway(gw.Mock, record("first"), record("second"))
+		_, err := instrumented.GetCollection(ctx, flow.HexToID("01"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"first GetCollection", "second GetCollection"}, calls)
+
+		// the context passed by the last interceptor is used for the call
+		callCtx := gw.Mock.Calls[0].Arguments.Get(0).(context.Context)
+		assert.Equal(t, "second", callCtx.Value(contextKey{}))
+	})
+
+	t.Run("Abort call from interceptor", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		abort := func(context.Context, string, func(context.Context) error) error {
+			return errors.New("aborted")
+		}
+
+		_, err := gateway.NewInstrumentedGateway(gw.Mock, abort).GetLatestBlock(ctx)
+		assert.EqualError(t, err, "aborted")
+		gw.Mock.AssertNotCalled(t, mocks.GetLatestBlockFunc, mock.Anything)
+	})
+}
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	cadence "github.com/onflow/cadence"
+
 	flow "github.com/onflow/flow-go-sdk"
 
 	mock "github.com/stretchr/testify/mock"
@@ -14,25 +17,25 @@ type Gateway struct {
 	mock.Mock
 }
 
-// ExecuteScript provides a mock function with given fields: _a0, _a1
-func (_m *Gateway) ExecuteScript(_a0 []byte, _a1 []cadence.Value) (cadence.Value, error) {
-	ret := _m.Called(_a0, _a1)
+// ExecuteScript provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) ExecuteScript(_a0 context.Context, _a1 []byte, _a2 []cadence.Value) (cadence.Value, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 cadence.Value
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value) (cadence.Value, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value) (cadence.Value, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value) cadence.Value); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value) cadence.Value); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(cadence.Value)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]byte, []cadence.Value) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []cadence.Value) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,25 +43,25 @@ func (_m *Gateway) ExecuteScript(_a0 []byte, _a1 []cadence.Value) (cadence.Value
 	return r0, r1
 }
 
-// ExecuteScriptAtHeight provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Gateway) ExecuteScriptAtHeight(_a0 []byte, _a1 []cadence.Value, _a2 uint64) (cadence.Value, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// ExecuteScriptAtHeight provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) ExecuteScriptAtHeight(_a0 context.Context, _a1 []byte, _a2 []cadence.Value, _a3 uint64) (cadence.Value, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 cadence.Value
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, uint64) (cadence.Value, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, uint64) (cadence.Value, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
 	}
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, uint64) cadence.Value); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, uint64) cadence.Value); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(cadence.Value)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]byte, []cadence.Value, uint64) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []cadence.Value, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,25 +69,25 @@ func (_m *Gateway) ExecuteScriptAtHeight(_a0 []byte, _a1 []cadence.Value, _a2 ui
 	return r0, r1
 }
 
-// ExecuteScriptAtID provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Gateway) ExecuteScriptAtID(_a0 []byte, _a1 []cadence.Value, _a2 flow.Identifier) (cadence.Value, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// ExecuteScriptAtID provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) ExecuteScriptAtID(_a0 context.Context, _a1 []byte, _a2 []cadence.Value, _a3 flow.Identifier) (cadence.Value, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 cadence.Value
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, flow.Identifier) (cadence.Value, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, flow.Identifier) (cadence.Value, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
 	}
-	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, flow.Identifier) cadence.Value); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []cadence.Value, flow.Identifier) cadence.Value); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(cadence.Value)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]byte, []cadence.Value, flow.Identifier) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []cadence.Value, flow.Identifier) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,25 +95,25 @@ func (_m *Gateway) ExecuteScriptAtID(_a0 []byte, _a1 []cadence.Value, _a2 flow.I
 	return r0, r1
 }
 
-// GetAccount provides a mock function with given fields: _a0
-func (_m *Gateway) GetAccount(_a0 flow.Address) (*flow.Account, error) {
-	ret := _m.Called(_a0)
+// GetAccount provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetAccount(_a0 context.Context, _a1 flow.Address) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Account
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Address) (*flow.Account, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) (*flow.Account, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Address) *flow.Account); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) *flow.Account); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Account)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Address) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,25 +121,51 @@ func (_m *Gateway) GetAccount(_a0 flow.Address) (*flow.Account, error) {
 	return r0, r1
 }
 
-// GetBlockByHeight provides a mock function with given fields: _a0
-func (_m *Gateway) GetBlockByHeight(_a0 uint64) (*flow.Block, error) {
-	ret := _m.Called(_a0)
+// GetAccountAtBlockHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetAccountAtBlockHeight(_a0 context.Context, _a1 flow.Address, _a2 uint64) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (*flow.Account, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHeight provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetBlockByHeight(_a0 context.Context, _a1 uint64) (*flow.Block, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Block
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*flow.Block, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*flow.Block, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *flow.Block); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *flow.Block); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Block)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -144,25 +173,25 @@ func (_m *Gateway) GetBlockByHeight(_a0 uint64) (*flow.Block, error) {
 	return r0, r1
 }
 
-// GetBlockByID provides a mock function with given fields: _a0
-func (_m *Gateway) GetBlockByID(_a0 flow.Identifier) (*flow.Block, error) {
-	ret := _m.Called(_a0)
+// GetBlockByID provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetBlockByID(_a0 context.Context, _a1 flow.Identifier) (*flow.Block, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Block
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier) (*flow.Block, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) (*flow.Block, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.Block); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) *flow.Block); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Block)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -170,25 +199,25 @@ func (_m *Gateway) GetBlockByID(_a0 flow.Identifier) (*flow.Block, error) {
 	return r0, r1
 }
 
-// GetCollection provides a mock function with given fields: _a0
-func (_m *Gateway) GetCollection(_a0 flow.Identifier) (*flow.Collection, error) {
-	ret := _m.Called(_a0)
+// GetCollection provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetCollection(_a0 context.Context, _a1 flow.Identifier) (*flow.Collection, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Collection
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier) (*flow.Collection, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) (*flow.Collection, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.Collection); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) *flow.Collection); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Collection)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,25 +225,25 @@ func (_m *Gateway) GetCollection(_a0 flow.Identifier) (*flow.Collection, error)
 	return r0, r1
 }
 
-// GetEvents provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Gateway) GetEvents(_a0 string, _a1 uint64, _a2 uint64) ([]flow.BlockEvents, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetEvents provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) GetEvents(_a0 context.Context, _a1 string, _a2 uint64, _a3 uint64) ([]flow.BlockEvents, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 []flow.BlockEvents
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, uint64, uint64) ([]flow.BlockEvents, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]flow.BlockEvents, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
 	}
-	if rf, ok := ret.Get(0).(func(string, uint64, uint64) []flow.BlockEvents); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []flow.BlockEvents); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]flow.BlockEvents)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -222,25 +251,25 @@ func (_m *Gateway) GetEvents(_a0 string, _a1 uint64, _a2 uint64) ([]flow.BlockEv
 	return r0, r1
 }
 
-// GetLatestBlock provides a mock function with given fields:
-func (_m *Gateway) GetLatestBlock() (*flow.Block, error) {
-	ret := _m.Called()
+// GetLatestBlock provides a mock function with given fields: _a0
+func (_m *Gateway) GetLatestBlock(_a0 context.Context) (*flow.Block, error) {
+	ret := _m.Called(_a0)
 
 	var r0 *flow.Block
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*flow.Block, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*flow.Block, error)); ok {
+		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func() *flow.Block); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *flow.Block); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Block)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -248,25 +277,25 @@ func (_m *Gateway) GetLatestBlock() (*flow.Block, error) {
 	return r0, r1
 }
 
-// GetLatestProtocolStateSnapshot provides a mock function with given fields:
-func (_m *Gateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
-	ret := _m.Called()
+// GetLatestProtocolStateSnapshot provides a mock function with given fields: _a0
+func (_m *Gateway) GetLatestProtocolStateSnapshot(_a0 context.Context) ([]byte, error) {
+	ret := _m.Called(_a0)
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]byte, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, error)); ok {
+		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func() []byte); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -274,25 +303,25 @@ func (_m *Gateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
 	return r0, r1
 }
 
-// GetTransaction provides a mock function with given fields: _a0
-func (_m *Gateway) GetTransaction(_a0 flow.Identifier) (*flow.Transaction, error) {
-	ret := _m.Called(_a0)
+// GetTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetTransaction(_a0 context.Context, _a1 flow.Identifier) (*flow.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier) (*flow.Transaction, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) (*flow.Transaction, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.Transaction); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) *flow.Transaction); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -300,25 +329,25 @@ func (_m *Gateway) GetTransaction(_a0 flow.Identifier) (*flow.Transaction, error
 	return r0, r1
 }
 
-// GetTransactionResult provides a mock function with given fields: _a0, _a1
-func (_m *Gateway) GetTransactionResult(_a0 flow.Identifier, _a1 bool) (*flow.TransactionResult, error) {
-	ret := _m.Called(_a0, _a1)
+// GetTransactionResult provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetTransactionResult(_a0 context.Context, _a1 flow.Identifier, _a2 bool) (*flow.TransactionResult, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 *flow.TransactionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier, bool) (*flow.TransactionResult, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, bool) (*flow.TransactionResult, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier, bool) *flow.TransactionResult); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, bool) *flow.TransactionResult); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.TransactionResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier, bool) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -326,25 +355,25 @@ func (_m *Gateway) GetTransactionResult(_a0 flow.Identifier, _a1 bool) (*flow.Tr
 	return r0, r1
 }
 
-// GetTransactionResultsByBlockID provides a mock function with given fields: blockID
-func (_m *Gateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
-	ret := _m.Called(blockID)
+// GetTransactionResultsByBlockID provides a mock function with given fields: ctx, blockID
+func (_m *Gateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	ret := _m.Called(ctx, blockID)
 
 	var r0 []*flow.TransactionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier) ([]*flow.TransactionResult, error)); ok {
-		return rf(blockID)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) ([]*flow.TransactionResult, error)); ok {
+		return rf(ctx, blockID)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier) []*flow.TransactionResult); ok {
-		r0 = rf(blockID)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) []*flow.TransactionResult); ok {
+		r0 = rf(ctx, blockID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*flow.TransactionResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
-		r1 = rf(blockID)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -352,25 +381,25 @@ func (_m *Gateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*f
 	return r0, r1
 }
 
-// GetTransactionsByBlockID provides a mock function with given fields: blockID
-func (_m *Gateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
-	ret := _m.Called(blockID)
+// GetTransactionsByBlockID provides a mock function with given fields: ctx, blockID
+func (_m *Gateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	ret := _m.Called(ctx, blockID)
 
 	var r0 []*flow.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier) ([]*flow.Transaction, error)); ok {
-		return rf(blockID)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) ([]*flow.Transaction, error)); ok {
+		return rf(ctx, blockID)
 	}
-	if rf, ok := ret.Get(0).(func(flow.Identifier) []*flow.Transaction); ok {
-		r0 = rf(blockID)
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) []*flow.Transaction); ok {
+		r0 = rf(ctx, blockID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*flow.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
-		r1 = rf(blockID)
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -406,25 +435,25 @@ func (_m *Gateway) SecureConnection() bool {
 	return r0
 }
 
-// SendSignedTransaction provides a mock function with given fields: _a0
-func (_m *Gateway) SendSignedTransaction(_a0 *flow.Transaction) (*flow.Transaction, error) {
-	ret := _m.Called(_a0)
+// SendSignedTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) SendSignedTransaction(_a0 context.Context, _a1 *flow.Transaction) (*flow.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *flow.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*flow.Transaction) (*flow.Transaction, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.Transaction) (*flow.Transaction, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*flow.Transaction) *flow.Transaction); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.Transaction) *flow.Transaction); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*flow.Transaction) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.Transaction) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
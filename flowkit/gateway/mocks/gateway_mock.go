@@ -27,22 +27,24 @@ import (
 )
 
 const (
-	GetAccountFunc            = "GetAccount"
-	SendSignedTransactionFunc = "SendSignedTransaction"
-	GetCollectionFunc         = "GetCollection"
-	GetTransactionResultFunc  = "GetTransactionResult"
-	GetEventsFunc             = "GetEvents"
-	GetLatestBlockFunc        = "GetLatestBlock"
-	GetBlockByHeightFunc      = "GetBlockByHeight"
-	GetBlockByIDFunc          = "GetBlockByID"
-	ExecuteScriptFunc         = "ExecuteScript"
-	GetTransactionFunc        = "GetTransaction"
+	GetAccountFunc              = "GetAccount"
+	GetAccountAtBlockHeightFunc = "GetAccountAtBlockHeight"
+	SendSignedTransactionFunc   = "SendSignedTransaction"
+	GetCollectionFunc           = "GetCollection"
+	GetTransactionResultFunc    = "GetTransactionResult"
+	GetEventsFunc               = "GetEvents"
+	GetLatestBlockFunc          = "GetLatestBlock"
+	GetBlockByHeightFunc        = "GetBlockByHeight"
+	GetBlockByIDFunc            = "GetBlockByID"
+	ExecuteScriptFunc           = "ExecuteScript"
+	GetTransactionFunc          = "GetTransaction"
 )
 
 type TestGateway struct {
 	Mock                           *Gateway
 	SendSignedTransaction          *mock.Call
 	GetAccount                     *mock.Call
+	GetAccountAtBlockHeight        *mock.Call
 	GetCollection                  *mock.Call
 	GetTransactionResult           *mock.Call
 	GetEvents                      *mock.Call
@@ -64,39 +66,52 @@ func DefaultMockGateway() *TestGateway {
 		Mock: m,
 		SendSignedTransaction: m.On(
 			SendSignedTransactionFunc,
+			mock.Anything,
 			mock.AnythingOfType("*flow.Transaction"),
 		),
 		GetAccount: m.On(
 			GetAccountFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Address"),
 		),
+		GetAccountAtBlockHeight: m.On(
+			GetAccountAtBlockHeightFunc,
+			mock.Anything,
+			mock.AnythingOfType("flow.Address"),
+			mock.AnythingOfType("uint64"),
+		),
 		GetCollection: m.On(
 			GetCollectionFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
 		GetTransactionResult: m.On(
 			GetTransactionResultFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 			mock.AnythingOfType("bool"),
 		),
 		GetTransaction: m.On(
 			GetTransactionFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
 		GetEvents: m.On(
 			GetEventsFunc,
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("uint64"),
 			mock.AnythingOfType("uint64"),
 		),
 		ExecuteScript: m.On(
 			ExecuteScriptFunc,
+			mock.Anything,
 			mock.AnythingOfType("[]uint8"),
 			mock.AnythingOfType("[]cadence.Value"),
 		),
-		GetBlockByHeight: m.On(GetBlockByHeightFunc, mock.Anything),
-		GetBlockByID:     m.On(GetBlockByIDFunc, mock.Anything),
-		GetLatestBlock:   m.On(GetLatestBlockFunc),
+		GetBlockByHeight: m.On(GetBlockByHeightFunc, mock.Anything, mock.Anything),
+		GetBlockByID:     m.On(GetBlockByIDFunc, mock.Anything, mock.Anything),
+		GetLatestBlock:   m.On(GetLatestBlockFunc, mock.Anything),
 	}
 
 	// default return values
@@ -105,10 +120,15 @@ func DefaultMockGateway() *TestGateway {
 	})
 
 	t.GetAccount.Run(func(args mock.Arguments) {
-		addr := args.Get(0).(flow.Address)
+		addr := args.Get(1).(flow.Address)
 		t.GetAccount.Return(tests.NewAccountWithAddress(addr.String()), nil)
 	})
 
+	t.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+		addr := args.Get(1).(flow.Address)
+		t.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(addr.String()), nil)
+	})
+
 	t.ExecuteScript.Run(func(args mock.Arguments) {
 		t.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 	})
@@ -0,0 +1,232 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Gateway = &RateLimitGateway{}
+
+// RateLimitGateway is a gateway decorator that limits the rate of calls to the wrapped gateway.
+//
+// Calls are delayed until the token bucket defined by the network rate limit allows them, or until
+// the context is done. Every call to the wrapped gateway takes a token, so when used with retrying
+// the rate limit gateway should be wrapped by the retry gateway to limit retried calls as well.
+type RateLimitGateway struct {
+	gateway Gateway
+	limit   config.NetworkRateLimit
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time
+}
+
+// NewRateLimitGateway wraps the gateway with the rate limit, if the rate limit doesn't define
+// requests per second the calls are not limited.
+func NewRateLimitGateway(gateway Gateway, limit config.NetworkRateLimit) *RateLimitGateway {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &RateLimitGateway{
+		gateway: gateway,
+		limit:   limit,
+		tokens:  float64(limit.Burst),
+		last:    time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns the delay until the token is available.
+func (g *RateLimitGateway) reserve() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	refill := now.Sub(g.last).Seconds() * g.limit.RequestsPerSecond
+	g.tokens = math.Min(float64(g.limit.Burst), g.tokens+refill)
+	g.last = now
+
+	g.tokens--
+	if g.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-g.tokens / g.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// release returns a reserved token that wasn't used.
+func (g *RateLimitGateway) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tokens++
+}
+
+// wait blocks until a call is allowed by the rate limit or the context is done.
+func (g *RateLimitGateway) wait(ctx context.Context) error {
+	if g.limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	delay := g.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		g.release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// withRateLimit calls the function once the rate limit allows it.
+func withRateLimit[T any](ctx context.Context, g *RateLimitGateway, call func() (T, error)) (T, error) {
+	if err := g.wait(ctx); err != nil {
+		var result T
+		return result, err
+	}
+
+	return call()
+}
+
+func (g *RateLimitGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return withRateLimit(ctx, g, func() (*flow.Account, error) {
+		return g.gateway.GetAccount(ctx, address)
+	})
+}
+
+func (g *RateLimitGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return withRateLimit(ctx, g, func() (*flow.Account, error) {
+		return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+	})
+}
+
+func (g *RateLimitGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return withRateLimit(ctx, g, func() (*flow.Transaction, error) {
+		return g.gateway.SendSignedTransaction(ctx, tx)
+	})
+}
+
+func (g *RateLimitGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return withRateLimit(ctx, g, func() (*flow.Transaction, error) {
+		return g.gateway.GetTransaction(ctx, ID)
+	})
+}
+
+func (g *RateLimitGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return withRateLimit(ctx, g, func() ([]*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+	})
+}
+
+// GetTransactionResult polls the result through the rate limit when waiting for the seal, so every request is limited.
+func (g *RateLimitGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	if waitSeal {
+		return WaitForTransactionResult(ctx, g, ID, flow.TransactionStatusSealed, DefaultPollInterval)
+	}
+
+	return withRateLimit(ctx, g, func() (*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResult(ctx, ID, false)
+	})
+}
+
+func (g *RateLimitGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return withRateLimit(ctx, g, func() ([]*flow.Transaction, error) {
+		return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *RateLimitGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return withRateLimit(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScript(ctx, script, arguments)
+	})
+}
+
+func (g *RateLimitGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return withRateLimit(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	})
+}
+
+func (g *RateLimitGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return withRateLimit(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	})
+}
+
+func (g *RateLimitGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return withRateLimit(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetLatestBlock(ctx)
+	})
+}
+
+func (g *RateLimitGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return withRateLimit(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (g *RateLimitGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return withRateLimit(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetBlockByID(ctx, ID)
+	})
+}
+
+func (g *RateLimitGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return withRateLimit(ctx, g, func() ([]flow.BlockEvents, error) {
+		return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+	})
+}
+
+func (g *RateLimitGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return withRateLimit(ctx, g, func() (*flow.Collection, error) {
+		return g.gateway.GetCollection(ctx, ID)
+	})
+}
+
+func (g *RateLimitGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return withRateLimit(ctx, g, func() ([]byte, error) {
+		return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+func (g *RateLimitGateway) Ping() error {
+	if err := g.wait(context.Background()); err != nil {
+		return err
+	}
+
+	return g.gateway.Ping()
+}
+
+func (g *RateLimitGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
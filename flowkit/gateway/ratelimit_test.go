@@ -0,0 +1,84 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func TestRateLimitGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Allow burst and then pace calls", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		limited := gateway.NewRateLimitGateway(gw.Mock, config.NetworkRateLimit{RequestsPerSecond: 20, Burst: 2})
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			_, err := limited.GetLatestBlock(ctx)
+			require.NoError(t, err)
+		}
+		assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+		for i := 0; i < 2; i++ {
+			_, err := limited.GetLatestBlock(ctx)
+			require.NoError(t, err)
+		}
+		// two more calls need two tokens refilled at 20 per second
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 4)
+	})
+
+	t.Run("Stop waiting when the context is done", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		limited := gateway.NewRateLimitGateway(gw.Mock, config.NetworkRateLimit{RequestsPerSecond: 0.1})
+
+		_, err := limited.GetEvents(ctx, "flow.AccountCreated", 0, 1)
+		require.NoError(t, err)
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = limited.GetEvents(timeout, "flow.AccountCreated", 0, 1)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetEventsFunc, 1)
+	})
+
+	t.Run("Don't limit without requests per second", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		limited := gateway.NewRateLimitGateway(gw.Mock, config.NetworkRateLimit{})
+
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			_, err := limited.GetCollection(ctx, flow.HexToID("01"))
+			require.NoError(t, err)
+		}
+		assert.Less(t, time.Since(start), 40*time.Millisecond)
+	})
+}
@@ -0,0 +1,335 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/access/http/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// restAPIPath is the path of the Access REST API used when the host doesn't define one.
+const restAPIPath = "/v1"
+
+// IsRestHost checks whether the network host points to the Access REST API, which is the case for http and https URLs.
+func IsRestHost(host string) bool {
+	return strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://")
+}
+
+var _ Gateway = &RestGateway{}
+
+// RestGateway is a gateway implementation that uses the Flow Access REST API.
+//
+// It can be used where gRPC connections are not possible, for example behind proxies not supporting HTTP/2.
+type RestGateway struct {
+	client     *httpAccess.Client
+	httpClient *http.Client
+	host       string
+	secure     bool
+}
+
+// NewRestGateway returns a new REST gateway, the network host must be an http or https URL.
+//
+// The network headers are attached to every request sent to the host.
+func NewRestGateway(network config.Network) (*RestGateway, error) {
+	u, err := url.Parse(network.Host)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid REST API host %s, must be an http or https URL", network.Host)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = restAPIPath
+	}
+	host := strings.TrimSuffix(u.String(), "/")
+
+	client, err := httpAccess.NewClient(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
+	}
+
+	// the headers are only attached by the HTTP client of this gateway, so other requests to the host don't send them
+	httpClient := http.DefaultClient
+	if len(network.Headers) > 0 {
+		httpClient = newHeaderClient(network.HeaderValues())
+		if err := setRestHTTPClient(client, httpClient); err != nil {
+			return nil, fmt.Errorf("failed to set the headers of network %s: %w", network.Name, err)
+		}
+	}
+
+	return &RestGateway{
+		client:     client,
+		httpClient: httpClient,
+		host:       host,
+		secure:     u.Scheme == "https",
+	}, nil
+}
+
+// restStatusCodes maps REST API status codes to the equivalent gRPC codes.
+var restStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusRequestTimeout:      codes.DeadlineExceeded,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// restError converts REST API errors to gRPC status errors,
+// so they can be handled the same way as errors returned by the gRPC gateway.
+func restError(err error) error {
+	var httpErr httpAccess.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+
+	code, ok := restStatusCodes[httpErr.Code]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// withRestError converts any error returned by the REST API call.
+func withRestError[T any](value T, err error) (T, error) {
+	return value, restError(err)
+}
+
+// get requests the REST API resource and decodes the response into the model.
+func (g *RestGateway) get(ctx context.Context, path string, query url.Values, model any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", g.host, path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		httpErr := httpAccess.HTTPError{Url: req.URL.String(), Code: res.StatusCode, Message: string(body)}
+		_ = json.Unmarshal(body, &httpErr)
+		return restError(httpErr)
+	}
+
+	return json.Unmarshal(body, model)
+}
+
+// GetAccount gets an account by address from the Flow Access API.
+func (g *RestGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s: %w", address, restError(err))
+	}
+
+	return account, nil
+}
+
+// GetAccountAtBlockHeight gets an account by address as it was at the block height from the Flow Access API.
+func (g *RestGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s at height %d: %w", address, height, restError(err))
+	}
+
+	return account, nil
+}
+
+// SendSignedTransaction sends a transaction to flow that is already prepared and signed.
+func (g *RestGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	err := g.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transaction: %w", restError(err))
+	}
+
+	return tx, nil
+}
+
+// GetTransaction gets a transaction by ID from the Flow Access API.
+func (g *RestGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return withRestError(g.client.GetTransaction(ctx, ID))
+}
+
+// GetTransactionResultsByBlockID gets results of all the transactions in the block.
+//
+// The REST API doesn't provide results by block so they are fetched for each transaction in the block collections,
+// which means the system transaction result is not included.
+func (g *RestGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	IDs, err := g.blockTransactionIDs(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*flow.TransactionResult, 0, len(IDs))
+	for _, ID := range IDs {
+		result, err := g.GetTransactionResult(ctx, ID, false)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetTransactionResult gets a transaction result by ID from the Flow Access API, waiting for it to be sealed if waitSeal is set.
+func (g *RestGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	if waitSeal {
+		return WaitForTransactionResult(ctx, g, ID, flow.TransactionStatusSealed, DefaultPollInterval)
+	}
+
+	return withRestError(g.client.GetTransactionResult(ctx, ID))
+}
+
+// GetTransactionsByBlockID gets all the transactions in the block collections.
+//
+// The REST API doesn't provide transactions by block so the system transaction is not included.
+func (g *RestGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	IDs, err := g.blockTransactionIDs(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*flow.Transaction, 0, len(IDs))
+	for _, ID := range IDs {
+		tx, err := g.GetTransaction(ctx, ID)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// blockTransactionIDs gets IDs of all the transactions in the block collections in order.
+func (g *RestGateway) blockTransactionIDs(ctx context.Context, blockID flow.Identifier) ([]flow.Identifier, error) {
+	block, err := g.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	IDs := make([]flow.Identifier, 0)
+	for _, guarantee := range block.CollectionGuarantees {
+		collection, err := g.GetCollection(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+		IDs = append(IDs, collection.TransactionIDs...)
+	}
+
+	return IDs, nil
+}
+
+// ExecuteScript executes a script on Flow through the Access API.
+func (g *RestGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return withRestError(g.client.ExecuteScriptAtLatestBlock(ctx, script, arguments))
+}
+
+// ExecuteScriptAtHeight executes a script at block height.
+func (g *RestGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return withRestError(g.client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments))
+}
+
+// ExecuteScriptAtID executes a script at block ID.
+func (g *RestGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return withRestError(g.client.ExecuteScriptAtBlockID(ctx, ID, script, arguments))
+}
+
+// GetLatestBlock gets the latest sealed block on Flow through the Access API.
+func (g *RestGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return withRestError(g.client.GetLatestBlock(ctx, true))
+}
+
+// GetBlockByID get block by ID from the Flow Access API.
+func (g *RestGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return withRestError(g.client.GetBlockByID(ctx, ID))
+}
+
+// GetBlockByHeight get block by height from the Flow Access API.
+func (g *RestGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return withRestError(g.client.GetBlockByHeight(ctx, height))
+}
+
+// GetEvents gets events by name and block range from the Flow Access API.
+func (g *RestGateway) GetEvents(
+	ctx context.Context,
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	return withRestError(g.client.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight))
+}
+
+// GetCollection gets a collection by ID from the Flow Access API.
+//
+// The collection is requested directly since the transactions must be expanded to get their IDs.
+func (g *RestGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	var collection models.Collection
+	err := g.get(ctx, fmt.Sprintf("/collections/%s", ID), url.Values{"expand": {"transactions"}}, &collection)
+	if err != nil {
+		return nil, fmt.Errorf("get collection ID %s failed: %w", ID, err)
+	}
+
+	IDs := make([]flow.Identifier, len(collection.Transactions))
+	for i, tx := range collection.Transactions {
+		IDs[i] = flow.HexToID(tx.Id)
+	}
+
+	return &flow.Collection{TransactionIDs: IDs}, nil
+}
+
+// GetLatestProtocolStateSnapshot is not supported by the REST API.
+func (g *RestGateway) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
+	return nil, status.Error(codes.Unimplemented, "getting the protocol state snapshot is not supported by the REST API, use a gRPC host instead")
+}
+
+// Ping is used to check if the access node is alive and healthy.
+func (g *RestGateway) Ping() error {
+	return restError(g.client.Ping(context.Background()))
+}
+
+// SecureConnection returns whether the REST API is accessed over https.
+func (g *RestGateway) SecureConnection() bool {
+	return g.secure
+}
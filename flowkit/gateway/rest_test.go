@@ -0,0 +1,181 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/http/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+const (
+	testBlockID      = "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"
+	testCollectionID = "1b6a4f1a3a5e2e7f0c1d0c86e0f1bfbf4cfb6f2b1c3d6f1a0a7d3f2b1c0d9e8f"
+	testTxID         = "c1a5f7e96b06a5e1e2e6c3d9f1b4a3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6"
+)
+
+func newRestServer(t *testing.T) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, code int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	block := &models.Block{
+		Header: &models.BlockHeader{
+			Id:        testBlockID,
+			ParentId:  testBlockID,
+			Height:    "42",
+			Timestamp: time.Now(),
+		},
+		Payload: &models.BlockPayload{
+			CollectionGuarantees: []models.CollectionGuarantee{{CollectionId: testCollectionID}},
+			BlockSeals:           []models.BlockSeal{},
+		},
+		BlockStatus: "BLOCK_SEALED",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/blocks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sealed", r.URL.Query().Get("height"))
+		assert.Empty(t, r.Header.Get("x-api-key"))
+		writeJSON(w, http.StatusOK, []*models.Block{block})
+	})
+	mux.HandleFunc("/v1/collections/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "transactions", r.URL.Query().Get("expand"))
+		writeJSON(w, http.StatusOK, models.Collection{
+			Id:           testCollectionID,
+			Transactions: []models.Transaction{{Id: testTxID}},
+		})
+	})
+	mux.HandleFunc("/v1/scripts", func(w http.ResponseWriter, r *http.Request) {
+		result := jsoncdc.MustEncode(cadence.NewInt(42))
+		writeJSON(w, http.StatusOK, base64.StdEncoding.EncodeToString(result))
+	})
+	mux.HandleFunc("/v1/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, models.ModelError{Code: http.StatusNotFound, Message: "account not found"})
+	})
+	mux.HandleFunc("/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusServiceUnavailable, models.ModelError{Code: http.StatusServiceUnavailable, Message: "unavailable"})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRestGateway(t *testing.T) {
+	ctx := context.Background()
+	server := newRestServer(t)
+
+	gw, err := gateway.NewRestGateway(config.Network{Name: "rest", Host: server.URL})
+	require.NoError(t, err)
+
+	t.Run("Get latest block", func(t *testing.T) {
+		block, err := gw.GetLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToID(testBlockID), block.ID)
+		assert.Equal(t, uint64(42), block.Height)
+		assert.Equal(t, flow.HexToID(testCollectionID), block.CollectionGuarantees[0].CollectionID)
+	})
+
+	t.Run("Get collection", func(t *testing.T) {
+		collection, err := gw.GetCollection(ctx, flow.HexToID(testCollectionID))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Identifier{flow.HexToID(testTxID)}, collection.TransactionIDs)
+	})
+
+	t.Run("Execute script", func(t *testing.T) {
+		value, err := gw.ExecuteScript(ctx, []byte("pub fun main(): Int { return 42 }"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "42", value.String())
+	})
+
+	t.Run("Convert errors to status codes", func(t *testing.T) {
+		_, err := gw.GetAccount(ctx, flow.HexToAddress("0x01"))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = gw.GetEvents(ctx, "flow.AccountCreated", 0, 10)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		assert.NoError(t, gw.Ping())
+		assert.False(t, gw.SecureConnection())
+	})
+
+	t.Run("Invalid host", func(t *testing.T) {
+		_, err := gateway.NewRestGateway(config.Network{Name: "rest", Host: "127.0.0.1:8888"})
+		assert.EqualError(t, err, "invalid REST API host 127.0.0.1:8888, must be an http or https URL")
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		received := make(chan string, 2)
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v1/blocks", func(w http.ResponseWriter, r *http.Request) {
+			received <- r.Header.Get("x-api-key")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		mux.HandleFunc("/v1/collections/", func(w http.ResponseWriter, r *http.Request) {
+			received <- r.Header.Get("x-api-key")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		headerServer := httptest.NewServer(mux)
+		defer headerServer.Close()
+
+		headerGw, err := gateway.NewRestGateway(config.Network{
+			Name:    "rest",
+			Host:    headerServer.URL,
+			Headers: []config.NetworkHeader{{Name: "x-api-key", Value: "secret"}},
+		})
+		require.NoError(t, err)
+
+		_, _ = headerGw.GetLatestBlock(ctx)
+		assert.Equal(t, "secret", <-received)
+		_, _ = headerGw.GetCollection(ctx, flow.HexToID(testCollectionID))
+		assert.Equal(t, "secret", <-received)
+
+		// requests to other hosts don't get the headers
+		_, err = gw.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+
+		// the default client is not changed, so other requests to the host don't get the headers
+		assert.Nil(t, http.DefaultClient.Transport)
+		res, err := http.Get(headerServer.URL + "/v1/blocks")
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Empty(t, <-received)
+	})
+}
@@ -0,0 +1,224 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// DefaultRetry is used for any retry value not provided in the network configuration.
+var DefaultRetry = config.NetworkRetry{
+	MaxAttempts: 5,
+	MinBackoff:  250 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+var _ Gateway = &RetryGateway{}
+
+// RetryGateway is a gateway decorator that retries idempotent calls when the access node
+// returns a transient error (unavailable, resource exhausted or deadline exceeded).
+//
+// Retries are delayed using exponential backoff with full jitter. Sending transactions is never
+// retried since the transaction might have been accepted even if an error was returned.
+type RetryGateway struct {
+	gateway Gateway
+	retry   config.NetworkRetry
+}
+
+// NewRetryGateway wraps the gateway with retrying behaviour defined by the network retry configuration.
+func NewRetryGateway(gateway Gateway, retry config.NetworkRetry) *RetryGateway {
+	if retry.MaxAttempts == 0 {
+		retry.MaxAttempts = DefaultRetry.MaxAttempts
+	}
+	if retry.MinBackoff == 0 {
+		retry.MinBackoff = DefaultRetry.MinBackoff
+	}
+	if retry.MaxBackoff == 0 {
+		retry.MaxBackoff = DefaultRetry.MaxBackoff
+	}
+	if retry.MaxBackoff < retry.MinBackoff {
+		retry.MaxBackoff = retry.MinBackoff
+	}
+
+	return &RetryGateway{
+		gateway: gateway,
+		retry:   retry,
+	}
+}
+
+// isRetryable checks whether the error is a transient access node error.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the next attempt, attempts are counted from zero.
+func (g *RetryGateway) backoff(attempt int) time.Duration {
+	delay := g.retry.MaxBackoff
+	if attempt < 32 { // avoid overflowing the shift
+		if d := g.retry.MinBackoff << attempt; d > 0 && d < delay {
+			delay = d
+		}
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// withRetry calls the function until it succeeds, returns a non-retryable error,
+// runs out of attempts or the context is done, in which case the context error is returned.
+func withRetry[T any](ctx context.Context, g *RetryGateway, call func() (T, error)) (T, error) {
+	var result T
+	var err error
+
+	for attempt := 0; attempt < g.retry.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(g.backoff(attempt - 1)):
+			}
+		}
+
+		result, err = call()
+		if err == nil || !isRetryable(err) {
+			return result, err
+		}
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+	}
+
+	return result, err
+}
+
+func (g *RetryGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return withRetry(ctx, g, func() (*flow.Account, error) {
+		return g.gateway.GetAccount(ctx, address)
+	})
+}
+
+func (g *RetryGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return withRetry(ctx, g, func() (*flow.Account, error) {
+		return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+	})
+}
+
+// SendSignedTransaction is not retried, since a failed response doesn't mean the transaction wasn't submitted.
+func (g *RetryGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *RetryGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return withRetry(ctx, g, func() (*flow.Transaction, error) {
+		return g.gateway.GetTransaction(ctx, ID)
+	})
+}
+
+func (g *RetryGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return withRetry(ctx, g, func() ([]*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *RetryGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return withRetry(ctx, g, func() (*flow.TransactionResult, error) {
+		return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+	})
+}
+
+func (g *RetryGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return withRetry(ctx, g, func() ([]*flow.Transaction, error) {
+		return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+	})
+}
+
+func (g *RetryGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return withRetry(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScript(ctx, script, arguments)
+	})
+}
+
+func (g *RetryGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return withRetry(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	})
+}
+
+func (g *RetryGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return withRetry(ctx, g, func() (cadence.Value, error) {
+		return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	})
+}
+
+func (g *RetryGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return withRetry(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetLatestBlock(ctx)
+	})
+}
+
+func (g *RetryGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return withRetry(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (g *RetryGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return withRetry(ctx, g, func() (*flow.Block, error) {
+		return g.gateway.GetBlockByID(ctx, ID)
+	})
+}
+
+func (g *RetryGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return withRetry(ctx, g, func() ([]flow.BlockEvents, error) {
+		return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+	})
+}
+
+func (g *RetryGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return withRetry(ctx, g, func() (*flow.Collection, error) {
+		return g.gateway.GetCollection(ctx, ID)
+	})
+}
+
+func (g *RetryGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return withRetry(ctx, g, func() ([]byte, error) {
+		return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+func (g *RetryGateway) Ping() error {
+	return g.gateway.Ping()
+}
+
+func (g *RetryGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+var testRetry = config.NetworkRetry{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  time.Millisecond,
+}
+
+func TestRetryGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Retry transient errors", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		block := tests.NewBlock()
+		gw.GetLatestBlock.Return(nil, status.Error(codes.Unavailable, "unavailable")).Times(2)
+		gw.Mock.On(mocks.GetLatestBlockFunc, mock.Anything).Return(block, nil)
+
+		result, err := gateway.NewRetryGateway(gw.Mock, testRetry).GetLatestBlock(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, block, result)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 3)
+	})
+
+	t.Run("Stop after max attempts", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		gw.GetEvents.Return(nil, status.Error(codes.ResourceExhausted, "rate limited"))
+
+		_, err := gateway.NewRetryGateway(gw.Mock, testRetry).GetEvents(ctx, "flow.AccountCreated", 0, 1)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetEventsFunc, 3)
+	})
+
+	t.Run("Don't retry other errors", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(nil, status.Error(codes.NotFound, "not found"))
+		})
+
+		_, err := gateway.NewRetryGateway(gw.Mock, testRetry).GetAccount(ctx, flow.HexToAddress("0x01"))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 1)
+	})
+
+	t.Run("Don't retry sending transactions", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			gw.SendSignedTransaction.Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		})
+
+		_, err := gateway.NewRetryGateway(gw.Mock, testRetry).SendSignedTransaction(ctx, tests.NewTransaction())
+		assert.Error(t, err)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("Stop when context is cancelled", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		gw.GetLatestBlock.Return(nil, status.Error(codes.Unavailable, "unavailable"))
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := gateway.NewRetryGateway(gw.Mock, testRetry).GetLatestBlock(cancelCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 1)
+	})
+
+	t.Run("Return context error when cancelled during backoff", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		cancelCtx, cancel := context.WithCancel(ctx)
+		gw.GetLatestBlock.Run(func(args mock.Arguments) {
+			time.AfterFunc(10*time.Millisecond, cancel)
+		}).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+
+		retry := config.NetworkRetry{MaxAttempts: 3, MinBackoff: time.Minute, MaxBackoff: time.Minute}
+		_, err := gateway.NewRetryGateway(gw.Mock, retry).GetLatestBlock(cancelCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetLatestBlockFunc, 1)
+	})
+}
@@ -0,0 +1,425 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-emulator/convert"
+	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage"
+	"github.com/onflow/flow-emulator/storage/util"
+	"github.com/onflow/flow-emulator/types"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	flowGo "github.com/onflow/flow-go/model/flow"
+)
+
+// SimulationResult is the outcome of a transaction executed by the simulator, changes made by it are discarded.
+type SimulationResult struct {
+	Result          *flow.TransactionResult
+	ComputationUsed uint64
+	Logs            []string
+}
+
+// SimulatorGateway is an emulator gateway used to execute transactions without submitting them to a network.
+//
+// Transaction signatures and sequence numbers are not validated and fees and storage limits are disabled,
+// so transactions built for another network can be executed after seeding the accounts they use.
+type SimulatorGateway struct {
+	*EmulatorGateway
+	chain    flowGo.Chain
+	store    *simulatorStore
+	sequence uint64
+}
+
+// NewSimulatorGateway creates a simulator using the addresses of the chain.
+func NewSimulatorGateway(chainID flow.ChainID) *SimulatorGateway {
+	store, err := util.CreateDefaultStorage()
+	if err != nil {
+		panic(err)
+	}
+	simulatorStore := &simulatorStore{Store: store}
+
+	emulatorGateway := NewEmulatorGatewayWithOpts(nil, WithEmulatorOptions(
+		emulator.WithStore(simulatorStore),
+		emulator.WithChainID(flowGo.ChainID(chainID)),
+		emulator.WithTransactionValidationEnabled(false),
+		emulator.WithTransactionFeesEnabled(false),
+		emulator.WithStorageLimitEnabled(false),
+	))
+
+	return &SimulatorGateway{
+		EmulatorGateway: emulatorGateway,
+		chain:           flowGo.ChainID(chainID).Chain(),
+		store:           simulatorStore,
+	}
+}
+
+// simulatorStore is the emulator storage which also writes the registers set by the simulator
+// when the next block is committed, registers written by the block itself take precedence.
+type simulatorStore struct {
+	storage.Store
+	registers map[flowGo.RegisterID]flowGo.RegisterValue
+}
+
+func (s *simulatorStore) CommitBlock(
+	ctx context.Context,
+	block flowGo.Block,
+	collections []*flowGo.LightCollection,
+	transactions map[flowGo.Identifier]*flowGo.TransactionBody,
+	transactionResults map[flowGo.Identifier]*types.StorableTransactionResult,
+	executionSnapshot *snapshot.ExecutionSnapshot,
+	events []flowGo.Event,
+) error {
+	if len(s.registers) > 0 {
+		if executionSnapshot.WriteSet == nil {
+			executionSnapshot.WriteSet = make(map[flowGo.RegisterID]flowGo.RegisterValue)
+		}
+		for id, value := range s.registers {
+			if _, ok := executionSnapshot.WriteSet[id]; !ok {
+				executionSnapshot.WriteSet[id] = value
+			}
+		}
+		s.registers = nil
+	}
+
+	return s.Store.CommitBlock(ctx, block, collections, transactions, transactionResults, executionSnapshot, events)
+}
+
+// seededAccount is an account created in the simulator at the address index.
+type seededAccount struct {
+	index   uint64
+	account *flow.Account
+}
+
+// ChainForAddress returns the ID of the chain the address is valid on, checking mainnet, testnet and emulator addresses.
+func ChainForAddress(address flow.Address) (flow.ChainID, error) {
+	for _, chainID := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Emulator} {
+		if address.IsValid(chainID) {
+			return chainID, nil
+		}
+	}
+
+	return "", fmt.Errorf("address %s is not valid on any known chain", address)
+}
+
+// SeedAccounts creates the accounts with the same addresses, keys, balances and contracts in the simulator.
+//
+// Accounts that already exist in the simulator are skipped. The storage of the accounts is not copied. Each account
+// is created at its address by setting the address index of the simulator before creating it, and an error is
+// returned if the address is not valid on the simulator chain.
+func (g *SimulatorGateway) SeedAccounts(_ context.Context, accounts []*flow.Account) error {
+	seeded := make([]seededAccount, 0)
+	for _, account := range accounts {
+		if g.accountExists(account.Address) {
+			continue
+		}
+		index, err := g.chain.IndexFromAddress(flowGo.Address(account.Address))
+		if err != nil {
+			return fmt.Errorf("failed to seed account %s: %w", account.Address, err)
+		}
+		seeded = append(seeded, seededAccount{index: index, account: account})
+	}
+
+	sort.Slice(seeded, func(i, j int) bool {
+		return seeded[i].index < seeded[j].index
+	})
+
+	last, err := g.addressIndex()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range seeded {
+		// the next account is created at the index following the address index of the simulator
+		if err := g.setAddressIndex(s.index - 1); err != nil {
+			return err
+		}
+
+		address, err := g.createAccount(s.account.Keys)
+		if err != nil {
+			return err
+		}
+		if address != s.account.Address {
+			return fmt.Errorf("failed to seed account %s, created account %s instead", s.account.Address, address)
+		}
+
+		if err := g.fund(s.account); err != nil {
+			return err
+		}
+	}
+
+	// accounts created by simulated transactions must not reuse the address of an account that already exists
+	if len(seeded) > 0 && seeded[len(seeded)-1].index < last {
+		if err := g.setAddressIndex(last); err != nil {
+			return err
+		}
+	}
+
+	return g.deployContracts(seeded)
+}
+
+// deployContracts deploys the contracts of the seeded accounts in rounds, so contracts are deployed after their imports.
+func (g *SimulatorGateway) deployContracts(seeded []seededAccount) error {
+	type pendingContract struct {
+		address flow.Address
+		name    string
+		code    []byte
+	}
+
+	pending := make([]pendingContract, 0)
+	for _, s := range seeded {
+		names := make([]string, 0, len(s.account.Contracts))
+		for name := range s.account.Contracts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			pending = append(pending, pendingContract{
+				address: s.account.Address,
+				name:    name,
+				code:    s.account.Contracts[name],
+			})
+		}
+	}
+
+	for len(pending) > 0 {
+		failed := make([]pendingContract, 0)
+		var lastErr error
+
+		for _, contract := range pending {
+			tx := templates.AddAccountContract(contract.address, templates.Contract{
+				Name:   contract.name,
+				Source: string(contract.code),
+			})
+
+			if _, err := g.execute(tx); err != nil {
+				failed = append(failed, contract)
+				lastErr = fmt.Errorf("failed to seed contract %s on account %s: %w", contract.name, contract.address, err)
+			}
+		}
+
+		if len(failed) == len(pending) {
+			return lastErr
+		}
+		pending = failed
+	}
+
+	return nil
+}
+
+// addressIndex returns the address index of the last account created in the simulator.
+func (g *SimulatorGateway) addressIndex() (uint64, error) {
+	block, err := g.emulator.GetLatestBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	ledger, err := g.store.LedgerByHeight(context.Background(), block.Header.Height)
+	if err != nil {
+		return 0, err
+	}
+
+	state, err := ledger.Get(flowGo.AddressStateRegisterID)
+	if err != nil {
+		return 0, err
+	}
+
+	return g.chain.BytesToAddressGenerator(state).AddressCount(), nil
+}
+
+// setAddressIndex sets the address index of the simulator by committing an empty block which writes the
+// address generator state, so the next account is created at the following index.
+func (g *SimulatorGateway) setAddressIndex(index uint64) error {
+	// the address generator state is the index encoded as a 48 bit big endian integer
+	state := make([]byte, 8)
+	binary.BigEndian.PutUint64(state, index)
+
+	g.store.registers = map[flowGo.RegisterID]flowGo.RegisterValue{
+		flowGo.AddressStateRegisterID: state[2:],
+	}
+
+	if _, err := g.emulator.CommitBlock(); err != nil {
+		return fmt.Errorf("failed to set the simulator address index: %w", err)
+	}
+
+	return nil
+}
+
+func (g *SimulatorGateway) accountExists(address flow.Address) bool {
+	_, err := g.emulator.GetAccount(flowGo.Address(address))
+	return err == nil
+}
+
+// createAccount creates an account with the keys that are not revoked and returns its address.
+func (g *SimulatorGateway) createAccount(keys []*flow.AccountKey) (flow.Address, error) {
+	activeKeys := make([]*flow.AccountKey, 0, len(keys))
+	for _, key := range keys {
+		if !key.Revoked {
+			activeKeys = append(activeKeys, key)
+		}
+	}
+
+	tx, err := templates.CreateAccount(activeKeys, nil, g.serviceAddress())
+	if err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	result, err := g.execute(tx)
+	if err != nil {
+		return flow.EmptyAddress, fmt.Errorf("failed to seed account: %w", err)
+	}
+
+	for _, event := range result.Events {
+		if event.Type == flow.EventAccountCreated {
+			return flow.AccountCreatedEvent(event).Address(), nil
+		}
+	}
+
+	return flow.EmptyAddress, fmt.Errorf("failed to seed account, account created event not found")
+}
+
+const fundAccountTemplate = `
+import FungibleToken from 0x%s
+import FlowToken from 0x%s
+
+transaction(amount: UFix64, to: Address) {
+	prepare(signer: AuthAccount) {
+		let vault = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)
+			?? panic("Could not borrow the service account vault")
+		let receiver = getAccount(to).getCapability(/public/flowTokenReceiver)
+			.borrow<&{FungibleToken.Receiver}>()
+			?? panic("Could not borrow the account receiver")
+
+		receiver.deposit(from: <-vault.withdraw(amount: amount))
+	}
+}`
+
+// fund transfers FLOW from the service account so the seeded account has the same balance.
+func (g *SimulatorGateway) fund(account *flow.Account) error {
+	created, err := g.emulator.GetAccount(flowGo.Address(account.Address))
+	if err != nil {
+		return err
+	}
+	if account.Balance <= created.Balance {
+		return nil
+	}
+
+	script := fmt.Sprintf(fundAccountTemplate, fvm.FungibleTokenAddress(g.chain).Hex(), fvm.FlowTokenAddress(g.chain).Hex())
+	tx := flow.NewTransaction().
+		SetScript([]byte(script)).
+		AddAuthorizer(g.serviceAddress())
+
+	if err := tx.AddArgument(cadence.UFix64(account.Balance - created.Balance)); err != nil {
+		return err
+	}
+	if err := tx.AddArgument(cadence.NewAddress(account.Address)); err != nil {
+		return err
+	}
+
+	if _, err := g.execute(tx); err != nil {
+		return fmt.Errorf("failed to seed balance of account %s: %w", account.Address, err)
+	}
+
+	return nil
+}
+
+func (g *SimulatorGateway) serviceAddress() flow.Address {
+	return g.emulator.ServiceKey().Address
+}
+
+// execute runs the seeding transaction using the service account as the proposer and payer and commits the changes.
+func (g *SimulatorGateway) execute(tx *flow.Transaction) (*types.TransactionResult, error) {
+	block, err := g.emulator.GetLatestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// sequence numbers are not validated, but they keep transactions with the same content unique
+	g.sequence++
+	tx.SetProposalKey(g.serviceAddress(), 0, g.sequence).
+		SetPayer(g.serviceAddress()).
+		SetReferenceBlockID(flow.Identifier(block.ID())).
+		SetGasLimit(flow.DefaultTransactionGasLimit)
+
+	if err := g.emulator.AddTransaction(*convert.SDKTransactionToFlow(*tx)); err != nil {
+		return nil, err
+	}
+
+	result, err := g.emulator.ExecuteNextTransaction()
+	if err != nil {
+		_ = g.emulator.ResetPendingBlock()
+		return nil, err
+	}
+
+	if _, err := g.emulator.CommitBlock(); err != nil {
+		return nil, err
+	}
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return result, nil
+}
+
+// SimulateTransaction executes the transaction without committing the changes it makes.
+//
+// The reference block of the transaction is replaced with the latest simulator block,
+// so the ID of the simulated transaction differs from the original.
+func (g *SimulatorGateway) SimulateTransaction(_ context.Context, tx *flow.Transaction) (*SimulationResult, error) {
+	block, err := g.emulator.GetLatestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := *tx
+	simulated.ReferenceBlockID = flow.Identifier(block.ID())
+
+	if err := g.emulator.AddTransaction(*convert.SDKTransactionToFlow(simulated)); err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+	defer func() { _ = g.emulator.ResetPendingBlock() }()
+
+	result, err := g.emulator.ExecuteNextTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResult{
+		Result: &flow.TransactionResult{
+			Status:        flow.TransactionStatusExecuted,
+			Error:         result.Error,
+			Events:        result.Events,
+			TransactionID: simulated.ID(),
+			BlockID:       flow.Identifier(block.ID()),
+			BlockHeight:   block.Header.Height + 1,
+		},
+		ComputationUsed: result.ComputationUsed,
+		Logs:            result.Logs,
+	}, nil
+}
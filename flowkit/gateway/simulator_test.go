@@ -0,0 +1,170 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+func TestSimulatorGateway(t *testing.T) {
+	ctx := context.Background()
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("simulator-test-seed-simulator-test-seed"))
+	require.NoError(t, err)
+
+	address := flow.NewAddressGenerator(flow.Emulator).SetIndex(10).Address()
+	account := &flow.Account{
+		Address: address,
+		Balance: 100_000_000_000,
+		Keys: []*flow.AccountKey{{
+			PublicKey: privateKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    flow.AccountKeyWeightThreshold,
+		}},
+		Contracts: map[string][]byte{
+			"Counter": []byte(`
+				pub contract Counter {
+					pub event Incremented(count: Int)
+					pub var count: Int
+					pub fun increment() {
+						self.count = self.count + 1
+						emit Incremented(count: self.count)
+					}
+					init() { self.count = 0 }
+				}`),
+		},
+	}
+
+	simulator := gateway.NewSimulatorGateway(flow.Emulator)
+	err = simulator.SeedAccounts(ctx, []*flow.Account{account})
+	require.NoError(t, err)
+
+	t.Run("Seed accounts", func(t *testing.T) {
+		seeded, err := simulator.GetAccount(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, account.Balance, seeded.Balance)
+		assert.Equal(t, account.Keys[0].PublicKey, seeded.Keys[0].PublicKey)
+		assert.Contains(t, seeded.Contracts, "Counter")
+	})
+
+	t.Run("Simulate transaction", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(fmt.Sprintf(`
+				import Counter from 0x%s
+				transaction {
+					prepare(signer: AuthAccount) {
+						Counter.increment()
+						log(signer.address)
+					}
+				}`, address.Hex()))).
+			SetProposalKey(address, 0, 0).
+			SetPayer(address).
+			AddAuthorizer(address).
+			SetGasLimit(1000)
+
+		// changes are discarded so the result is the same every time
+		for i := 0; i < 2; i++ {
+			result, err := simulator.SimulateTransaction(ctx, tx)
+			require.NoError(t, err)
+			require.NoError(t, result.Result.Error)
+			assert.Equal(t, flow.TransactionStatusExecuted, result.Result.Status)
+			assert.Greater(t, result.ComputationUsed, uint64(0))
+			assert.Equal(t, []string{fmt.Sprintf("0x%s", address.Hex())}, result.Logs)
+
+			require.Len(t, result.Result.Events, 1)
+			assert.Equal(t, "1", result.Result.Events[0].Value.Fields[0].String())
+		}
+	})
+
+	t.Run("Simulate failing transaction", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { execute { panic("failed") } }`)).
+			SetProposalKey(address, 0, 0).
+			SetPayer(address).
+			SetGasLimit(1000)
+
+		result, err := simulator.SimulateTransaction(ctx, tx)
+		require.NoError(t, err)
+		require.Error(t, result.Result.Error)
+		assert.Contains(t, result.Result.Error.Error(), "failed")
+	})
+
+	t.Run("Seed accounts with a high address index", func(t *testing.T) {
+		highAddress := flow.NewAddressGenerator(flow.Emulator).SetIndex(5_000_000).Address()
+		lowAddress := flow.NewAddressGenerator(flow.Emulator).SetIndex(20).Address()
+
+		err := simulator.SeedAccounts(ctx, []*flow.Account{{Address: highAddress}})
+		require.NoError(t, err)
+		err = simulator.SeedAccounts(ctx, []*flow.Account{{Address: lowAddress}})
+		require.NoError(t, err)
+
+		for _, seeded := range []flow.Address{highAddress, lowAddress} {
+			_, err := simulator.GetAccount(ctx, seeded)
+			assert.NoError(t, err)
+		}
+
+		// accounts created by simulated transactions follow the highest seeded account
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: AuthAccount) { AuthAccount(payer: signer) } }`)).
+			SetProposalKey(address, 0, 0).
+			SetPayer(address).
+			AddAuthorizer(address).
+			SetGasLimit(1000)
+
+		result, err := simulator.SimulateTransaction(ctx, tx)
+		require.NoError(t, err)
+		require.NoError(t, result.Result.Error)
+
+		var created flow.Address
+		for _, event := range result.Result.Events {
+			if event.Type == flow.EventAccountCreated {
+				created = flow.AccountCreatedEvent(event).Address()
+			}
+		}
+		assert.Equal(t, flow.NewAddressGenerator(flow.Emulator).SetIndex(5_000_001).Address(), created)
+	})
+
+	t.Run("Fail seeding accounts of another chain", func(t *testing.T) {
+		err := simulator.SeedAccounts(ctx, []*flow.Account{{Address: flow.HexToAddress("0x9a0766d93b6608b7")}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Chain for address", func(t *testing.T) {
+		chainID, err := gateway.ChainForAddress(flow.HexToAddress("0x1654653399040a61"))
+		require.NoError(t, err)
+		assert.Equal(t, flow.Mainnet, chainID)
+
+		chainID, err = gateway.ChainForAddress(address)
+		require.NoError(t, err)
+		assert.Equal(t, flow.Emulator, chainID)
+
+		_, err = gateway.ChainForAddress(flow.HexToAddress("0x01"))
+		assert.Error(t, err)
+	})
+}
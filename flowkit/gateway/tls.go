@@ -0,0 +1,62 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// NewTLSConfig creates the TLS configuration from the network TLS files.
+//
+// The server certificate is verified using the CA bundle if provided or the system certificates otherwise,
+// and the client certificate is presented to the server for mutual TLS if provided.
+func NewTLSConfig(network config.NetworkTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: network.ServerName,
+	}
+
+	if network.CAFile != "" {
+		ca, err := os.ReadFile(network.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA file %s", network.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if network.CertFile != "" || network.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(network.CertFile, network.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
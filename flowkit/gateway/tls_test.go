@@ -0,0 +1,188 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCert creates a certificate signed by the parent, or a self-signed CA certificate if the parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+// writeFiles writes the certificate and key PEM files to the directory.
+func (c *testCert) writeFiles(t *testing.T, dir string, name string) (string, string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "access.internal", ca)
+	client := newTestCert(t, "client", ca)
+
+	caFile, _ := ca.writeFiles(t, dir, "ca")
+	certFile, keyFile := client.writeFiles(t, dir, "client")
+
+	t.Run("Mutual TLS handshake", func(t *testing.T) {
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(ca.cert)
+
+		listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: []tls.Certificate{server.tlsCertificate()},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		handshake := make(chan error, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				handshake <- err
+				return
+			}
+			defer conn.Close()
+			handshake <- conn.(*tls.Conn).Handshake()
+		}()
+
+		tlsConfig, err := gateway.NewTLSConfig(config.NetworkTLS{
+			CAFile:     caFile,
+			CertFile:   certFile,
+			KeyFile:    keyFile,
+			ServerName: "access.internal",
+		})
+		require.NoError(t, err)
+
+		conn, err := tls.Dial("tcp", listener.Addr().String(), tlsConfig)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.Handshake())
+		require.NoError(t, <-handshake)
+		assert.Equal(t, "access.internal", conn.ConnectionState().PeerCertificates[0].Subject.CommonName)
+	})
+
+	t.Run("Reject server not signed by the CA", func(t *testing.T) {
+		other := newTestCert(t, "access.internal", newTestCert(t, "other", nil))
+
+		listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: []tls.Certificate{other.tlsCertificate()},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		go func() {
+			conn, err := listener.Accept()
+			if err == nil {
+				_ = conn.(*tls.Conn).Handshake()
+				_ = conn.Close()
+			}
+		}()
+
+		tlsConfig, err := gateway.NewTLSConfig(config.NetworkTLS{CAFile: caFile, ServerName: "access.internal"})
+		require.NoError(t, err)
+
+		_, err = tls.Dial("tcp", listener.Addr().String(), tlsConfig)
+		var unknownAuthority x509.UnknownAuthorityError
+		assert.ErrorAs(t, err, &unknownAuthority)
+	})
+
+	t.Run("Use system certificates without CA file", func(t *testing.T) {
+		tlsConfig, err := gateway.NewTLSConfig(config.NetworkTLS{ServerName: "access.internal"})
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.Empty(t, tlsConfig.Certificates)
+		assert.Equal(t, "access.internal", tlsConfig.ServerName)
+	})
+
+	t.Run("Fail with invalid files", func(t *testing.T) {
+		_, err := gateway.NewTLSConfig(config.NetworkTLS{CAFile: filepath.Join(dir, "missing.pem")})
+		assert.ErrorContains(t, err, "failed to read CA file")
+
+		_, err = gateway.NewTLSConfig(config.NetworkTLS{CAFile: keyFile})
+		assert.EqualError(t, err, "no valid PEM certificates found in CA file "+keyFile)
+
+		_, err = gateway.NewTLSConfig(config.NetworkTLS{CertFile: certFile, KeyFile: caFile})
+		assert.ErrorContains(t, err, "failed to load client certificate")
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+// TracerName is the instrumentation name used for the gateway tracer.
+const TracerName = "github.com/onflow/flow-cli/flowkit/gateway"
+
+// NewTracingInterceptor returns an interceptor that records every gateway call as an OpenTelemetry span,
+// the attributes are added to every span, for example to identify the access node host.
+//
+// Spans are named after the Access API method and record the gRPC status code of the result,
+// failed calls also record the error.
+func NewTracingInterceptor(tracer trace.Tracer, attributes ...attribute.KeyValue) Interceptor {
+	return func(ctx context.Context, method string, invoke func(context.Context) error) error {
+		ctx, span := tracer.Start(
+			ctx,
+			"AccessAPI/"+method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+			trace.WithAttributes(attribute.String("rpc.method", method)),
+		)
+		defer span.End()
+
+		err := invoke(ctx)
+		span.SetAttributes(attribute.String("rpc.status_code", status.Code(err).String()))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	ctx := context.Background()
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(gateway.TracerName)
+
+	gw := mocks.DefaultMockGateway()
+	gw.GetAccount.Run(func(args mock.Arguments) {
+		gw.GetAccount.Return(nil, status.Error(codes.NotFound, "not found"))
+	})
+	instrumented := gateway.NewInstrumentedGateway(
+		gw.Mock,
+		gateway.NewTracingInterceptor(tracer, attribute.String("net.peer.name", "access.devnet.nodes.onflow.org:9000")),
+	)
+
+	_, err := instrumented.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	_, err = instrumented.GetAccount(ctx, flow.HexToAddress("0x01"))
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "AccessAPI/GetLatestBlock", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("net.peer.name", "access.devnet.nodes.onflow.org:9000"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("rpc.status_code", "OK"))
+	assert.Equal(t, otelCodes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "AccessAPI/GetAccount", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("rpc.status_code", "NotFound"))
+	assert.Equal(t, otelCodes.Error, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1) // recorded error
+}
@@ -0,0 +1,70 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DefaultPollInterval is the interval between transaction result requests while waiting for a transaction status.
+const DefaultPollInterval = time.Second
+
+// WaitForTransactionResult polls the transaction result until the transaction reaches the status or the context is done.
+//
+// Statuses follow the transaction lifecycle, so waiting for the executed status also returns sealed results.
+// An error is returned if the transaction expires before reaching the status.
+func WaitForTransactionResult(
+	ctx context.Context,
+	gw Gateway,
+	ID flow.Identifier,
+	status flow.TransactionStatus,
+	interval time.Duration,
+) (*flow.TransactionResult, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := gw.GetTransactionResult(ctx, ID, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Status == flow.TransactionStatusExpired {
+			return nil, fmt.Errorf("transaction %s expired before it was %s", ID, strings.ToLower(status.String()))
+		}
+		if result.Status >= status {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func TestWaitForTransactionResult(t *testing.T) {
+	ctx := context.Background()
+	ID := flow.HexToID("01")
+
+	withStatuses := func(statuses ...flow.TransactionStatus) *mocks.TestGateway {
+		gw := mocks.DefaultMockGateway()
+		calls := 0
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			assert.False(t, args.Get(2).(bool))
+			status := statuses[calls]
+			if calls < len(statuses)-1 {
+				calls++
+			}
+			gw.GetTransactionResult.Return(&flow.TransactionResult{Status: status}, nil)
+		})
+		return gw
+	}
+
+	t.Run("Wait for status", func(t *testing.T) {
+		gw := withStatuses(flow.TransactionStatusPending, flow.TransactionStatusFinalized, flow.TransactionStatusExecuted)
+
+		result, err := gateway.WaitForTransactionResult(ctx, gw.Mock, ID, flow.TransactionStatusExecuted, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Status)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 3)
+	})
+
+	t.Run("Later status", func(t *testing.T) {
+		gw := withStatuses(flow.TransactionStatusSealed)
+
+		result, err := gateway.WaitForTransactionResult(ctx, gw.Mock, ID, flow.TransactionStatusFinalized, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
+	})
+
+	t.Run("Fail expired", func(t *testing.T) {
+		gw := withStatuses(flow.TransactionStatusPending, flow.TransactionStatusExpired)
+
+		_, err := gateway.WaitForTransactionResult(ctx, gw.Mock, ID, flow.TransactionStatusSealed, time.Millisecond)
+		assert.EqualError(t, err, "transaction 0100000000000000000000000000000000000000000000000000000000000000 expired before it was sealed")
+	})
+
+	t.Run("Fail timeout", func(t *testing.T) {
+		gw := withStatuses(flow.TransactionStatusPending)
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := gateway.WaitForTransactionResult(ctx, gw.Mock, ID, flow.TransactionStatusSealed, time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,261 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// deployHook is a hook of the deployment to the account.
+type deployHook struct {
+	config.DeploymentHook
+	account string
+	signer  string
+}
+
+// String describes the hook in logs and errors.
+func (h deployHook) String() string {
+	when := "after"
+	if h.Before {
+		when = "before"
+	}
+
+	if h.Contract != "" {
+		return fmt.Sprintf("%s %s contract %s", h.Transaction, when, h.Contract)
+	}
+	return fmt.Sprintf("%s %s deploying to account %s", h.Transaction, when, h.account)
+}
+
+// newDeployHooks returns the hooks of the deployments in the order they are configured.
+func newDeployHooks(deployments config.Deployments) []deployHook {
+	hooks := make([]deployHook, 0)
+	for _, deployment := range deployments {
+		for _, hook := range deployment.Hooks {
+			hooks = append(hooks, deployHook{
+				DeploymentHook: hook,
+				account:        deployment.Account,
+				signer:         deployment.SignerOf(hook),
+			})
+		}
+	}
+	return hooks
+}
+
+// hooksOf returns the hooks of the account running before or after the contract,
+// or for an empty contract name the hooks running before or after all contracts.
+func (d *projectDeploy) hooksOf(account string, contract string, before bool) []deployHook {
+	hooks := make([]deployHook, 0)
+	for _, hook := range d.hooks {
+		if hook.account == account && hook.Contract == contract && hook.Before == before {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// contractHooks returns the hooks to run after the contract was deployed, hooks only run if the deploy added or
+// updated the contract, so they don't run again for contracts that didn't change.
+func (d *projectDeploy) contractHooks(contract *project.Contract) []deployHook {
+	if !d.changed(func(change contractChange) bool { return change.contract == contract }) {
+		return nil
+	}
+	return d.hooksOf(contract.AccountName, contract.Name, false)
+}
+
+// deploymentHooks returns the hooks to run after all the contracts were deployed,
+// for the accounts that had any of their contracts added or updated.
+func (d *projectDeploy) deploymentHooks() []deployHook {
+	hooks := make([]deployHook, 0)
+	for _, hook := range d.hooks {
+		if hook.Contract == "" && !hook.Before && d.changed(func(change contractChange) bool {
+			return change.contract.AccountName == hook.account
+		}) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// beforeContractHooks returns the hooks to run before the contract is deployed, hooks only run if the contract
+// will be added or updated, so they don't run for contracts that didn't change.
+func (f *Flowkit) beforeContractHooks(
+	ctx context.Context,
+	state *State,
+	contract *project.Contract,
+	run *projectDeploy,
+) ([]deployHook, error) {
+	hooks := run.hooksOf(contract.AccountName, contract.Name, true)
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	changes, err := f.contractChanges(ctx, state, contract, run)
+	if err != nil || !changes {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// beforeDeploymentHooks returns the hooks to run before any contract is deployed,
+// for the accounts that will have any of their contracts added or updated.
+func (f *Flowkit) beforeDeploymentHooks(
+	ctx context.Context,
+	state *State,
+	contracts []*project.Contract,
+	run *projectDeploy,
+) ([]deployHook, error) {
+	hooks := make([]deployHook, 0)
+	for _, hook := range run.hooks {
+		if hook.Contract != "" || !hook.Before {
+			continue
+		}
+
+		for _, contract := range contracts {
+			if contract.AccountName != hook.account {
+				continue
+			}
+
+			changes, err := f.contractChanges(ctx, state, contract, run)
+			if err != nil {
+				return nil, err
+			}
+			if changes {
+				hooks = append(hooks, hook)
+				break
+			}
+		}
+	}
+	return hooks, nil
+}
+
+// contractChanges checks whether deploying the contract will add or update it, the same way deployContract skips
+// contracts matching the lockfile or the deployed code, and contracts that exist but are not updated.
+func (f *Flowkit) contractChanges(
+	ctx context.Context,
+	state *State,
+	contract *project.Contract,
+	run *projectDeploy,
+) (bool, error) {
+	script := Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()}
+	program, name, err := f.resolveContract(state, script)
+	if err != nil {
+		return false, err
+	}
+
+	entry := newLockedContract(contract, program)
+	if _, unchanged := lockedContract(run.lock, f.network.Name, contract.Name, entry); unchanged && !run.options.ignoreLockfile {
+		return false, nil
+	}
+
+	account, err := f.gateway.GetAccount(ctx, contract.AccountAddress)
+	if err != nil {
+		return false, err
+	}
+
+	existing, exists := account.Contracts[name]
+	if !exists {
+		return true, nil
+	}
+	return !bytes.Equal(existing, program.Code()) && run.update(existing, program.Code()), nil
+}
+
+func (d *projectDeploy) changed(match func(change contractChange) bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, change := range d.changes {
+		if match(change) {
+			return true
+		}
+	}
+	return false
+}
+
+// runDeployHooks runs the hooks one by one, stopping at the first hook that fails which is recorded in the deploy error.
+func (f *Flowkit) runDeployHooks(
+	ctx context.Context,
+	state *State,
+	hooks []deployHook,
+	deployErr *ProjectDeploymentError,
+) bool {
+	for _, hook := range hooks {
+		err := f.runDeployHook(ctx, state, hook)
+		if err != nil {
+			deployErr.addHook(hook, err)
+			return false
+		}
+	}
+	return true
+}
+
+// runDeployHook sends the hook transaction, which the signer proposes, authorizes and pays for.
+func (f *Flowkit) runDeployHook(ctx context.Context, state *State, hook deployHook) error {
+	signer, err := state.Accounts().ByName(hook.signer)
+	if err != nil {
+		return fmt.Errorf("signer account %s not found in configuration", hook.signer)
+	}
+
+	code, err := state.ReadFile(hook.Transaction)
+	if err != nil {
+		return fmt.Errorf("failed to read transaction %s: %w", hook.Transaction, err)
+	}
+
+	roles := transactions.AccountRoles{
+		Proposer:    *signer,
+		Authorizers: []accounts.Account{*signer},
+		Payer:       *signer,
+	}
+	script := Script{Code: code, Args: hook.Args, Location: hook.Transaction}
+
+	tx, err := f.BuildTransaction(ctx, roles.AddressRoles(), signer.Key.Index(), script, flow.DefaultTransactionGasLimit)
+	if err != nil {
+		return err
+	}
+	if err = tx.SetSigner(signer); err != nil {
+		return err
+	}
+	tx, err = tx.Sign()
+	if err != nil {
+		return err
+	}
+
+	// the hook only succeeded once it is executed, regardless of the wait strategy
+	ID, err := f.sendExecutedTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	f.logger.Info(fmt.Sprintf(
+		"%s -> 0x%s (%s) [hook]",
+		output.Italic(hook.String()),
+		signer.Address,
+		ID.String(),
+	))
+	return nil
+}
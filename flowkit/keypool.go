@@ -0,0 +1,180 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+// ProposalKey is a proposer key handed out by the key pool together with the sequence number to use.
+type ProposalKey struct {
+	Index          int
+	SequenceNumber uint64
+}
+
+// KeyPool hands out proposal keys of an account, so concurrent transactions proposed by the same
+// account each use a different key and don't conflict on sequence numbers.
+//
+// The pool contains all the full weight keys on the account that are not revoked and have the same public key
+// and hash algorithm as the account key, so every key can be signed with the same signer.
+// Sequence numbers are tracked locally and are refetched from the network when a key might be out of sync.
+type KeyPool struct {
+	proposer *accounts.Account
+	gateway  gateway.Gateway
+	mu       sync.Mutex
+	sequence map[int]uint64
+	stale    map[int]bool
+	free     chan int
+}
+
+// NewKeyPool creates a key pool for the proposer account using the keys found on the network.
+func NewKeyPool(ctx context.Context, gw gateway.Gateway, proposer *accounts.Account) (*KeyPool, error) {
+	signer, err := proposer.Key.Signer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := gw.GetAccount(ctx, proposer.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposer account %s: %w", proposer.Address, err)
+	}
+
+	pool := &KeyPool{
+		proposer: proposer,
+		gateway:  gw,
+		sequence: make(map[int]uint64),
+		stale:    make(map[int]bool),
+		free:     make(chan int, len(account.Keys)),
+	}
+
+	for _, key := range account.Keys {
+		if key.Revoked || key.HashAlgo != proposer.Key.HashAlgo() || !key.PublicKey.Equals(signer.PublicKey()) {
+			continue
+		}
+		// the proposer key also signs the envelope, so it must be able to authorize the transaction alone
+		if key.Weight < flow.AccountKeyWeightThreshold {
+			continue
+		}
+		pool.sequence[key.Index] = key.SequenceNumber
+		pool.free <- key.Index
+	}
+
+	if len(pool.sequence) == 0 {
+		return nil, fmt.Errorf("no full weight keys on account %s match the proposer key", proposer.Address)
+	}
+
+	return pool, nil
+}
+
+// Size returns the number of keys in the pool, which is the number of transactions that can be proposed concurrently.
+func (k *KeyPool) Size() int {
+	return len(k.sequence)
+}
+
+// Acquire waits for a free key and returns it, the key must be released after the transaction is finished.
+func (k *KeyPool) Acquire(ctx context.Context) (ProposalKey, error) {
+	var index int
+	select {
+	case index = <-k.free:
+	case <-ctx.Done():
+		return ProposalKey{}, ctx.Err()
+	}
+
+	k.mu.Lock()
+	stale := k.stale[index]
+	sequence := k.sequence[index]
+	k.mu.Unlock()
+
+	if stale {
+		account, err := k.gateway.GetAccount(ctx, k.proposer.Address)
+		if err != nil {
+			k.free <- index
+			return ProposalKey{}, fmt.Errorf("failed to get proposer account %s: %w", k.proposer.Address, err)
+		}
+		found := false
+		for _, key := range account.Keys {
+			if key.Index == index {
+				sequence = key.SequenceNumber
+				found = true
+			}
+		}
+		if !found {
+			k.free <- index
+			return ProposalKey{}, fmt.Errorf("failed to retrieve proposer key at index %d", index)
+		}
+
+		k.mu.Lock()
+		k.sequence[index] = sequence
+		k.stale[index] = false
+		k.mu.Unlock()
+	}
+
+	return ProposalKey{Index: index, SequenceNumber: sequence}, nil
+}
+
+// Release returns the key to the pool.
+//
+// If the transaction was executed the sequence number is incremented, otherwise the sequence number
+// is refetched from the network the next time the key is acquired.
+func (k *KeyPool) Release(key ProposalKey, executed bool) {
+	k.mu.Lock()
+	if executed {
+		k.sequence[key.Index] = key.SequenceNumber + 1
+	} else {
+		k.stale[key.Index] = true
+	}
+	k.mu.Unlock()
+
+	k.free <- key.Index
+}
+
+// Account returns the proposer account signing with the key.
+func (k *KeyPool) Account(key ProposalKey) *accounts.Account {
+	return &accounts.Account{
+		Name:    k.proposer.Name,
+		Address: k.proposer.Address,
+		Key:     pooledKey{Key: k.proposer.Key, index: key.Index},
+	}
+}
+
+// pooledKey signs with the proposer key using the index of the key acquired from the pool.
+type pooledKey struct {
+	accounts.Key
+	index int
+}
+
+func (p pooledKey) Index() int {
+	return p.index
+}
+
+// invalidSequenceNumberCode is the code of the error returned when the proposal key sequence number is not valid.
+const invalidSequenceNumberCode = "[Error Code: 1007]"
+
+// isSequenceNumberError checks whether the transaction failed because of the proposal key sequence number.
+func isSequenceNumberError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), invalidSequenceNumberCode)
+}
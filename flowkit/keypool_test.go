@@ -0,0 +1,171 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func TestKeyPool(t *testing.T) {
+	ctx := context.Background()
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseedseedseedPool"))
+	require.NoError(t, err)
+	otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseedseedseedOther"))
+	require.NoError(t, err)
+
+	proposer := &accounts.Account{
+		Name:    "Pool",
+		Address: flow.HexToAddress("0x01"),
+		Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, privateKey),
+	}
+
+	// a matching key which can't authorize transactions alone
+	partialKey := &flow.AccountKey{
+		Index:     4,
+		PublicKey: privateKey.PublicKey(),
+		SigAlgo:   crypto.ECDSA_P256,
+		HashAlgo:  crypto.SHA3_256,
+		Weight:    flow.AccountKeyWeightThreshold / 2,
+	}
+
+	newAccount := func(sequence uint64) *flow.Account {
+		key := func(index int, public crypto.PublicKey, revoked bool) *flow.AccountKey {
+			return &flow.AccountKey{
+				Index:          index,
+				PublicKey:      public,
+				SigAlgo:        crypto.ECDSA_P256,
+				HashAlgo:       crypto.SHA3_256,
+				Weight:         flow.AccountKeyWeightThreshold,
+				SequenceNumber: sequence,
+				Revoked:        revoked,
+			}
+		}
+
+		return &flow.Account{
+			Address: proposer.Address,
+			Keys: []*flow.AccountKey{
+				key(0, privateKey.PublicKey(), false),
+				key(1, otherKey.PublicKey(), false),
+				key(2, privateKey.PublicKey(), true),
+				key(3, privateKey.PublicKey(), false),
+				partialKey,
+			},
+		}
+	}
+
+	returnAccount := func(gw *mocks.TestGateway, account *flow.Account) {
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(account, nil)
+		})
+	}
+
+	t.Run("Pool matching keys", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		returnAccount(gw, newAccount(5))
+
+		pool, err := flowkit.NewKeyPool(ctx, gw.Mock, proposer)
+		require.NoError(t, err)
+		assert.Equal(t, 2, pool.Size())
+
+		first, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		second, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []int{0, 3}, []int{first.Index, second.Index})
+		assert.Equal(t, uint64(5), first.SequenceNumber)
+		assert.Equal(t, first.Index, pool.Account(first).Key.Index())
+		assert.Equal(t, proposer.Address, pool.Account(first).Address)
+	})
+
+	t.Run("Wait for free key", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		returnAccount(gw, newAccount(0))
+
+		pool, err := flowkit.NewKeyPool(ctx, gw.Mock, proposer)
+		require.NoError(t, err)
+
+		_, err = pool.Acquire(ctx)
+		require.NoError(t, err)
+		_, err = pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err = pool.Acquire(timeoutCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Release key", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		returnAccount(gw, newAccount(0))
+
+		pool, err := flowkit.NewKeyPool(ctx, gw.Mock, proposer)
+		require.NoError(t, err)
+		_, err = pool.Acquire(ctx) // keep one key in use
+		require.NoError(t, err)
+
+		key, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		pool.Release(key, true)
+
+		key, err = pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), key.SequenceNumber)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 1)
+
+		// a key that wasn't executed is refetched from the network
+		returnAccount(gw, newAccount(7))
+		pool.Release(key, false)
+
+		key, err = pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), key.SequenceNumber)
+		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
+	})
+
+	t.Run("Fail no matching keys", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		returnAccount(gw, &flow.Account{Address: proposer.Address, Keys: newAccount(0).Keys[1:3]})
+
+		_, err := flowkit.NewKeyPool(ctx, gw.Mock, proposer)
+		assert.EqualError(t, err, "no full weight keys on account 0000000000000001 match the proposer key")
+	})
+
+	t.Run("Fail no full weight keys", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		returnAccount(gw, &flow.Account{Address: proposer.Address, Keys: []*flow.AccountKey{partialKey}})
+
+		_, err := flowkit.NewKeyPool(ctx, gw.Mock, proposer)
+		assert.EqualError(t, err, "no full weight keys on account 0000000000000001 match the proposer key")
+	})
+}
@@ -0,0 +1,197 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// LockfileName is the name of the lockfile written next to the project configuration.
+const LockfileName = "flow.lock"
+
+// lockfileVersion is the version of the lockfile format.
+const lockfileVersion = 1
+
+// Lockfile records the contracts deployed by DeployProject on each network.
+//
+// The lockfile is used to skip contracts which didn't change since they were last deployed without fetching
+// the target accounts, and to report drift between the deployed contracts, local sources and on-chain code.
+// It is safe for concurrent use.
+type Lockfile struct {
+	mu       sync.Mutex
+	networks map[string]map[string]LockedContract
+}
+
+// LockedContract is the lockfile entry of a contract deployed on a network.
+//
+// SourceHash is the hash of the contract source file and CodeHash the hash of the deployed code,
+// which is the source with the imports replaced by the addresses they resolved to.
+type LockedContract struct {
+	Account       string            `json:"account"`
+	Address       string            `json:"address"`
+	SourceHash    string            `json:"sourceHash"`
+	CodeHash      string            `json:"codeHash"`
+	TransactionID string            `json:"transactionId,omitempty"`
+	BlockID       string            `json:"blockId,omitempty"`
+	BlockHeight   uint64            `json:"blockHeight,omitempty"`
+	Imports       map[string]string `json:"imports,omitempty"`
+}
+
+type lockfileJSON struct {
+	Version  int                                  `json:"version"`
+	Networks map[string]map[string]LockedContract `json:"networks"`
+}
+
+// NewLockfile returns an empty lockfile.
+func NewLockfile() *Lockfile {
+	return &Lockfile{networks: make(map[string]map[string]LockedContract)}
+}
+
+// ParseLockfile parses the lockfile data.
+func ParseLockfile(data []byte) (*Lockfile, error) {
+	var raw lockfileJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %w", err)
+	}
+	if raw.Version != lockfileVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %d", raw.Version)
+	}
+
+	lock := NewLockfile()
+	for network, contracts := range raw.Networks {
+		if contracts != nil {
+			lock.networks[network] = contracts
+		}
+	}
+	return lock, nil
+}
+
+// Serialize returns the lockfile data, contracts are sorted by network and name so the output is stable.
+func (l *Lockfile) Serialize() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return json.MarshalIndent(lockfileJSON{
+		Version:  lockfileVersion,
+		Networks: l.networks,
+	}, "", "\t")
+}
+
+// Contract returns the lockfile entry of the contract on the network.
+func (l *Lockfile) Contract(network string, name string) (LockedContract, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	contract, ok := l.networks[network][name]
+	return contract, ok
+}
+
+// Contracts returns all the lockfile entries for the network by contract name.
+func (l *Lockfile) Contracts(network string) map[string]LockedContract {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	contracts := make(map[string]LockedContract, len(l.networks[network]))
+	for name, contract := range l.networks[network] {
+		contracts[name] = contract
+	}
+	return contracts
+}
+
+// SetContract adds or replaces the lockfile entry of the contract on the network.
+func (l *Lockfile) SetContract(network string, name string, contract LockedContract) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.networks[network] == nil {
+		l.networks[network] = make(map[string]LockedContract)
+	}
+	l.networks[network][name] = contract
+}
+
+// RemoveContract removes the lockfile entry of the contract on the network.
+func (l *Lockfile) RemoveContract(network string, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.networks[network], name)
+	if len(l.networks[network]) == 0 {
+		delete(l.networks, network)
+	}
+}
+
+// ResetNetwork removes all the lockfile entries of the network.
+func (l *Lockfile) ResetNetwork(network string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.networks, network)
+}
+
+// HashCode returns the hash used by the lockfile to compare contract code.
+func HashCode(code []byte) string {
+	hash := sha256.Sum256(code)
+	return hex.EncodeToString(hash[:])
+}
+
+// LockfilePath returns the path of the lockfile, which is next to the loaded project configuration.
+func (p *State) LockfilePath() string {
+	if p.confLoader == nil || len(p.confLoader.LoadedLocations) == 0 {
+		return LockfileName
+	}
+
+	// the first location is the project configuration, following ones are merged into it
+	return filepath.Join(filepath.Dir(p.confLoader.LoadedLocations[0]), LockfileName)
+}
+
+// Lockfile loads the project lockfile, returning an empty lockfile if it doesn't exist yet.
+func (p *State) Lockfile() (*Lockfile, error) {
+	data, err := p.readerWriter.ReadFile(p.LockfilePath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewLockfile(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	return ParseLockfile(data)
+}
+
+// SaveLockfile writes the lockfile next to the project configuration.
+func (p *State) SaveLockfile(lock *Lockfile) error {
+	data, err := lock.Serialize()
+	if err != nil {
+		return err
+	}
+
+	err = p.readerWriter.WriteFile(p.LockfilePath(), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	return nil
+}
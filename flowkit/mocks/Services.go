@@ -127,25 +127,51 @@ func (_m *Services) CreateAccount(_a0 context.Context, _a1 *accounts.Account, _a
 	return r0, r1, r2
 }
 
-// DeployProject provides a mock function with given fields: _a0, _a1
-func (_m *Services) DeployProject(_a0 context.Context, _a1 flowkit.UpdateContract) ([]*project.Contract, error) {
-	ret := _m.Called(_a0, _a1)
+// DeployProject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) DeployProject(_a0 context.Context, _a1 flowkit.UpdateContract, _a2 ...flowkit.DeployOption) ([]*project.Contract, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 []*project.Contract
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract) ([]*project.Contract, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract, ...flowkit.DeployOption) ([]*project.Contract, error)); ok {
+		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract) []*project.Contract); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract, ...flowkit.DeployOption) []*project.Contract); ok {
+		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*project.Contract)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, flowkit.UpdateContract) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, flowkit.UpdateContract, ...flowkit.DeployOption) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeploymentStatus provides a mock function with given fields: _a0
+func (_m *Services) DeploymentStatus(_a0 context.Context) ([]flowkit.ContractStatus, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []flowkit.ContractStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]flowkit.ContractStatus, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []flowkit.ContractStatus); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flowkit.ContractStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -179,6 +205,32 @@ func (_m *Services) DerivePrivateKeyFromMnemonic(_a0 context.Context, _a1 string
 	return r0, r1
 }
 
+// EstimateTransaction provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) EstimateTransaction(_a0 context.Context, _a1 transactions.AccountRoles, _a2 flowkit.Script) (*flowkit.TransactionEstimate, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *flowkit.TransactionEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.AccountRoles, flowkit.Script) (*flowkit.TransactionEstimate, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.AccountRoles, flowkit.Script) *flowkit.TransactionEstimate); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flowkit.TransactionEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, transactions.AccountRoles, flowkit.Script) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Services) ExecuteScript(_a0 context.Context, _a1 flowkit.Script, _a2 flowkit.ScriptQuery) (cadence.Value, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -306,6 +358,32 @@ func (_m *Services) GetAccount(_a0 context.Context, _a1 flow.Address) (*flow.Acc
 	return r0, r1
 }
 
+// GetAccountAtBlockHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) GetAccountAtBlockHeight(_a0 context.Context, _a1 flow.Address, _a2 uint64) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (*flow.Account, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlock provides a mock function with given fields: _a0, _a1
 func (_m *Services) GetBlock(_a0 context.Context, _a1 flowkit.BlockQuery) (*flow.Block, error) {
 	ret := _m.Called(_a0, _a1)
@@ -468,6 +546,32 @@ func (_m *Services) Network() config.Network {
 	return r0
 }
 
+// PlanDeployment provides a mock function with given fields: _a0, _a1
+func (_m *Services) PlanDeployment(_a0 context.Context, _a1 flowkit.UpdateContract) ([]flowkit.ContractPlan, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []flowkit.ContractPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract) ([]flowkit.ContractPlan, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract) []flowkit.ContractPlan); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flowkit.ContractPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flowkit.UpdateContract) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Ping provides a mock function with given fields:
 func (_m *Services) Ping() error {
 	ret := _m.Called()
@@ -578,11 +682,42 @@ func (_m *Services) SendTransaction(_a0 context.Context, _a1 transactions.Accoun
 	return r0, r1, r2
 }
 
+// SendTransactions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) SendTransactions(_a0 context.Context, _a1 []flowkit.BatchTransaction, _a2 int) ([]flowkit.BatchResult, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []flowkit.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []flowkit.BatchTransaction, int) ([]flowkit.BatchResult, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []flowkit.BatchTransaction, int) []flowkit.BatchResult); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flowkit.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []flowkit.BatchTransaction, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetLogger provides a mock function with given fields: _a0
 func (_m *Services) SetLogger(_a0 output.Logger) {
 	_m.Called(_a0)
 }
 
+// SetWaitStrategy provides a mock function with given fields: _a0
+func (_m *Services) SetWaitStrategy(_a0 flowkit.WaitStrategy) {
+	_m.Called(_a0)
+}
+
 // SignTransactionPayload provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Services) SignTransactionPayload(_a0 context.Context, _a1 *accounts.Account, _a2 []byte) (*transactions.Transaction, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -609,6 +744,76 @@ func (_m *Services) SignTransactionPayload(_a0 context.Context, _a1 *accounts.Ac
 	return r0, r1
 }
 
+// SimulateTransaction provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Services) SimulateTransaction(_a0 context.Context, _a1 transactions.AccountRoles, _a2 flowkit.Script, _a3 uint64) (*flow.Transaction, *gateway.SimulationResult, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *flow.Transaction
+	var r1 *gateway.SimulationResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.AccountRoles, flowkit.Script, uint64) (*flow.Transaction, *gateway.SimulationResult, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.AccountRoles, flowkit.Script, uint64) *flow.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, transactions.AccountRoles, flowkit.Script, uint64) *gateway.SimulationResult); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*gateway.SimulationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, transactions.AccountRoles, flowkit.Script, uint64) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeEvents provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Services) SubscribeEvents(_a0 context.Context, _a1 []string, _a2 uint64, _a3 *flowkit.EventWorker) (<-chan flow.BlockEvents, <-chan error, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 <-chan flow.BlockEvents
+	var r1 <-chan error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64, *flowkit.EventWorker) (<-chan flow.BlockEvents, <-chan error, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64, *flowkit.EventWorker) <-chan flow.BlockEvents); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan flow.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, uint64, *flowkit.EventWorker) <-chan error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, uint64, *flowkit.EventWorker) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type mockConstructorTestingTNewServices interface {
 	mock.TestingT
 	Cleanup(func())
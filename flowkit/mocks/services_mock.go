@@ -23,7 +23,9 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
 	"github.com/onflow/flow-cli/flowkit/tests"
 )
@@ -33,7 +35,9 @@ const (
 	buildTransactionFunc             = "BuildTransaction"
 	createAccountFunc                = "CreateAccount"
 	deployProjectFunc                = "DeployProject"
+	deploymentStatusFunc             = "DeploymentStatus"
 	derivePrivateKeyFromMnemonicFunc = "DerivePrivateKeyFromMnemonic"
+	estimateTransactionFunc          = "EstimateTransaction"
 	gatewayFunc                      = "Gateway"
 	generateKeyFunc                  = "GenerateKey"
 	generateMnemonicKeyFunc          = "GenerateMnemonicKey"
@@ -42,10 +46,14 @@ const (
 	getTransactionsByBlockIDFunc     = "GetTransactionsByBlockID"
 	networkFunc                      = "Network"
 	pingFunc                         = "Ping"
+	planDeploymentFunc               = "PlanDeployment"
 	removeContractFunc               = "RemoveContract"
 	sendTransactionFunc              = "SendTransaction"
+	sendTransactionsFunc             = "SendTransactions"
 	setLoggerFunc                    = "SetLogger"
+	setWaitStrategyFunc              = "SetWaitStrategy"
 	signTransactionPayloadFunc       = "SignTransactionPayload"
+	simulateTransactionFunc          = "SimulateTransaction"
 	testFunc                         = "Test"
 )
 
@@ -55,7 +63,9 @@ type MockServices struct {
 	BuildTransaction             *mock.Call
 	CreateAccount                *mock.Call
 	DeployProject                *mock.Call
+	DeploymentStatus             *mock.Call
 	DerivePrivateKeyFromMnemonic *mock.Call
+	EstimateTransaction          *mock.Call
 	Gateway                      *mock.Call
 	GenerateKey                  *mock.Call
 	GenerateMnemonicKey          *mock.Call
@@ -64,12 +74,17 @@ type MockServices struct {
 	GetTransactionsByBlockID     *mock.Call
 	Network                      *mock.Call
 	Ping                         *mock.Call
+	PlanDeployment               *mock.Call
 	RemoveContract               *mock.Call
 	SendTransaction              *mock.Call
+	SendTransactions             *mock.Call
 	SetLogger                    *mock.Call
+	SetWaitStrategy              *mock.Call
 	SignTransactionPayload       *mock.Call
+	SimulateTransaction          *mock.Call
 	Test                         *mock.Call
 	GetAccount                   *mock.Call
+	GetAccountAtBlockHeight      *mock.Call
 	ExecuteScript                *mock.Call
 	SendSignedTransaction        *mock.Call
 	GetEvents                    *mock.Call
@@ -85,6 +100,12 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flow.Address"),
 		),
+		GetAccountAtBlockHeight: m.On(
+			mocks.GetAccountAtBlockHeightFunc,
+			mock.Anything,
+			mock.AnythingOfType("flow.Address"),
+			mock.AnythingOfType("uint64"),
+		),
 		ExecuteScript: m.On(
 			mocks.ExecuteScriptFunc,
 			mock.Anything,
@@ -134,7 +155,9 @@ func DefaultMockServices() *MockServices {
 			deployProjectFunc,
 			mock.Anything,
 			mock.AnythingOfType("flowkit.UpdateContract"),
+			mock.AnythingOfType("[]flowkit.DeployOption"),
 		),
+		DeploymentStatus: m.On(deploymentStatusFunc, mock.Anything),
 		DerivePrivateKeyFromMnemonic: m.On(
 			derivePrivateKeyFromMnemonicFunc,
 			mock.Anything,
@@ -142,6 +165,12 @@ func DefaultMockServices() *MockServices {
 			mock.AnythingOfType("crypto.SignatureAlgorithm"),
 			mock.AnythingOfType("string"),
 		),
+		EstimateTransaction: m.On(
+			estimateTransactionFunc,
+			mock.Anything,
+			mock.AnythingOfType("transactions.AccountRoles"),
+			mock.AnythingOfType("flowkit.Script"),
+		),
 		Gateway: m.On(gatewayFunc),
 		GenerateKey: m.On(
 			generateKeyFunc,
@@ -171,6 +200,11 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
+		PlanDeployment: m.On(
+			planDeploymentFunc,
+			mock.Anything,
+			mock.AnythingOfType("flowkit.UpdateContract"),
+		),
 		RemoveContract: m.On(
 			removeContractFunc,
 			mock.Anything,
@@ -184,21 +218,35 @@ func DefaultMockServices() *MockServices {
 			mock.AnythingOfType("flowkit.Script"),
 			mock.AnythingOfType("uint64"),
 		),
+		SendTransactions: m.On(
+			sendTransactionsFunc,
+			mock.Anything,
+			mock.AnythingOfType("[]flowkit.BatchTransaction"),
+			mock.AnythingOfType("int"),
+		),
 		SignTransactionPayload: m.On(
 			signTransactionPayloadFunc,
 			mock.Anything,
 			mock.AnythingOfType("*accounts.Account"),
 			mock.AnythingOfType("[]uint8"),
 		),
+		SimulateTransaction: m.On(
+			simulateTransactionFunc,
+			mock.Anything,
+			mock.AnythingOfType("transactions.AccountRoles"),
+			mock.AnythingOfType("flowkit.Script"),
+			mock.AnythingOfType("uint64"),
+		),
 		Test: m.On(
 			testFunc,
 			mock.Anything,
 			mock.AnythingOfType("[]byte"),
 			mock.AnythingOfType("string"),
 		),
-		Network:   m.On(networkFunc),
-		Ping:      m.On(pingFunc),
-		SetLogger: m.On(setLoggerFunc, mock.AnythingOfType("output.Logger")),
+		Network:         m.On(networkFunc),
+		Ping:            m.On(pingFunc),
+		SetLogger:       m.On(setLoggerFunc, mock.AnythingOfType("output.Logger")),
+		SetWaitStrategy: m.On(setWaitStrategyFunc, mock.AnythingOfType("flowkit.WaitStrategy")),
 	}
 
 	t.GetAccount.Run(func(args mock.Arguments) {
@@ -206,6 +254,11 @@ func DefaultMockServices() *MockServices {
 		t.GetAccount.Return(tests.NewAccountWithAddress(addr.String()), nil)
 	})
 
+	t.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+		addr := args.Get(1).(flow.Address)
+		t.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(addr.String()), nil)
+	})
+
 	t.ExecuteScript.Run(func(args mock.Arguments) {
 		t.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 	})
@@ -218,6 +271,22 @@ func DefaultMockServices() *MockServices {
 	t.RemoveContract.Return(flow.EmptyID, nil)
 	t.CreateAccount.Return(tests.NewAccountWithAddress("0x01"), flow.EmptyID, nil)
 	t.Network.Return(config.EmulatorNetwork)
+	t.PlanDeployment.Return([]flowkit.ContractPlan{}, nil)
+	t.DeploymentStatus.Return([]flowkit.ContractStatus{}, nil)
+	t.SendTransactions.Run(func(args mock.Arguments) {
+		txs := args.Get(1).([]flowkit.BatchTransaction)
+		results := make([]flowkit.BatchResult, len(txs))
+		for i := range txs {
+			results[i] = flowkit.BatchResult{Tx: tests.NewTransaction(), Result: tests.NewTransactionResult(nil)}
+		}
+		t.SendTransactions.Return(results, nil)
+	})
+
+	t.EstimateTransaction.Return(&flowkit.TransactionEstimate{ComputationUsed: 100, Fee: 1000}, nil)
+	t.SimulateTransaction.Return(tests.NewTransaction(), &gateway.SimulationResult{
+		Result:          tests.NewTransactionResult(nil),
+		ComputationUsed: 10,
+	}, nil)
 
 	return t
 }
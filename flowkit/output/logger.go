@@ -20,6 +20,7 @@ package output
 
 import (
 	"fmt"
+	"sync"
 )
 
 const (
@@ -47,8 +48,11 @@ func NewStdoutLogger(level int) *StdoutLogger {
 var _ Logger = &StdoutLogger{}
 
 // StdoutLogger is a stdout logging implementation.
+//
+// The logger is safe for concurrent use, since flowkit logs from multiple goroutines when deploying contracts concurrently.
 type StdoutLogger struct {
 	level   int
+	mu      sync.Mutex
 	spinner *Spinner
 }
 
@@ -61,15 +65,24 @@ func (s *StdoutLogger) log(msg string, level int) {
 }
 
 func (s *StdoutLogger) Info(msg string) {
-	s.StopProgress()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopProgress()
 	s.log(msg, InfoLog)
 }
 
 func (s *StdoutLogger) Debug(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.log(msg, DebugLog)
 }
 
 func (s *StdoutLogger) Error(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.log(fmt.Sprintf("%s %s", ErrorEmoji(), Red(msg)), ErrorLog)
 }
 
@@ -78,6 +91,9 @@ func (s *StdoutLogger) StartProgress(msg string) {
 		return
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.spinner != nil {
 		s.spinner.Stop()
 	}
@@ -87,6 +103,13 @@ func (s *StdoutLogger) StartProgress(msg string) {
 }
 
 func (s *StdoutLogger) StopProgress() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopProgress()
+}
+
+func (s *StdoutLogger) stopProgress() {
 	if s.level == NoneLog {
 		return
 	}
@@ -0,0 +1,141 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// ContractPlanAction is the action deploying the project would take for a contract.
+type ContractPlanAction string
+
+const (
+	// ContractAdd is planned for contracts that don't exist on the target account.
+	ContractAdd ContractPlanAction = "add"
+	// ContractUpdate is planned for contracts that exist on the target account with different code and can be updated.
+	ContractUpdate ContractPlanAction = "update"
+	// ContractUnchanged is planned for contracts that exist on the target account with the same code.
+	ContractUnchanged ContractPlanAction = "unchanged"
+	// ContractBlocked is planned for contracts that exist on the target account with different code but can't be updated,
+	// either because updating isn't enabled or because the update breaks the contract update rules.
+	ContractBlocked ContractPlanAction = "blocked"
+)
+
+// ContractPlan describes how deploying the project would change a contract on the network.
+//
+// Code contains the contract code with the imports replaced by the addresses they resolve to on the network,
+// and ExistingCode the code of the contract on the target account if it already exists.
+type ContractPlan struct {
+	Contract     *project.Contract
+	Action       ContractPlanAction
+	Imports      map[string]flow.Address
+	Code         []byte
+	ExistingCode []byte
+	Reason       string
+	// UpdateIssues are the issues found by checking the update against the contract update rules.
+	UpdateIssues project.UpdateIssues
+}
+
+// PlanDeployment returns the plan for deploying the project contracts to the network without sending any transactions.
+//
+// Contracts are returned in deployment order, each with the action deploying the project would take for it
+// depending on the contract existing on the target account and the update function.
+func (f *Flowkit) PlanDeployment(ctx context.Context, update UpdateContract) ([]ContractPlan, error) {
+	state, err := f.State()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(f.network)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := project.NewDeployment(contracts, state.AliasesForNetwork(f.network))
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	f.logger.StartProgress(fmt.Sprintf("Planning deployment of %d contracts...", len(sorted)))
+	defer f.logger.StopProgress()
+
+	// each target account is fetched only once, since it usually contains more than one contract
+	flowAccounts := make(map[flow.Address]*flow.Account)
+	plans := make([]ContractPlan, 0, len(sorted))
+	for _, contract := range sorted {
+		program, name, err := f.resolveContract(
+			state,
+			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve contract %s: %w", contract.Name, err)
+		}
+
+		flowAccount, ok := flowAccounts[contract.AccountAddress]
+		if !ok {
+			flowAccount, err = f.gateway.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get account %s: %w", contract.AccountAddress, err)
+			}
+			flowAccounts[contract.AccountAddress] = flowAccount
+		}
+
+		plan := ContractPlan{
+			Contract: contract,
+			Imports:  program.ContractImports(),
+			Code:     program.Code(),
+		}
+
+		existing, exists := flowAccount.Contracts[name]
+		switch {
+		case !exists:
+			plan.Action = ContractAdd
+		case bytes.Equal(existing, program.Code()):
+			plan.Action = ContractUnchanged
+			plan.ExistingCode = existing
+		case update(existing, program.Code()):
+			plan.Action = ContractUpdate
+			plan.ExistingCode = existing
+			plan.UpdateIssues = project.CheckContractUpdate(contract.AccountAddress, existing, program)
+			if len(plan.UpdateIssues.Errors()) > 0 {
+				plan.Action = ContractBlocked
+				plan.Reason = fmt.Sprintf("updating contract %s breaks the contract update rules", name)
+			}
+		default:
+			plan.Action = ContractBlocked
+			plan.ExistingCode = existing
+			plan.Reason = fmt.Sprintf("contract %s exists in account %s and updating it is not enabled", name, contract.AccountName)
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
@@ -20,6 +20,7 @@ package project
 
 import (
 	"fmt"
+	"sort"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
@@ -120,6 +121,65 @@ func (d *Deployment) Sort() ([]*Contract, error) {
 	return contracts, nil
 }
 
+// Levels groups contracts by dependency level in deployment order.
+//
+// Contracts in the first level don't import any other deployed contract, and contracts in each following level
+// only import contracts from the previous levels. Contracts in the same level don't depend on each other,
+// so they can be deployed at the same time once all the contracts of the previous levels are deployed.
+func (d *Deployment) Levels() ([][]*Contract, error) {
+	if d.conflictExists() {
+		return nil, fmt.Errorf("the same contract cannot be deployed to multiple accounts on the same network")
+	}
+
+	err := d.buildDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := sortByDeploymentOrder(d.contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	// dependencies are sorted before the contracts importing them, so their level is already known
+	contractLevels := make(map[int64]int)
+	var levels [][]*Contract
+	for _, c := range sorted {
+		level := 0
+		for _, dep := range c.dependencies {
+			if contractLevels[dep.index]+1 > level {
+				level = contractLevels[dep.index] + 1
+			}
+		}
+		contractLevels[c.index] = level
+
+		if level == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], c.Contract)
+	}
+
+	return levels, nil
+}
+
+// Dependencies returns the names of the deployed contracts imported by the contract, sorted by name.
+//
+// Dependencies are resolved when the contracts are sorted or grouped into levels.
+func (d *Deployment) Dependencies(name string) []string {
+	contract, ok := d.contractsByName[name]
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(contract.dependencies))
+	for _, dep := range contract.dependencies {
+		names = append(names, dep.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // conflictExists returns true if the same contract is configured to deploy to more than one account for the same network.
 func (d *Deployment) conflictExists() bool {
 	uniq := make(map[string]bool)
@@ -211,3 +211,79 @@ func TestContractDeploymentOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestContractDeploymentLevels(t *testing.T) {
+	newContracts := func(testContracts ...testContract) []*Contract {
+		contracts := make([]*Contract, len(testContracts))
+		for i, contract := range testContracts {
+			contracts[i] = NewContract(
+				strings.Split(contract.location, ".")[0],
+				contract.location,
+				contract.code,
+				contract.accountAddress,
+				contract.accountName,
+				nil,
+			)
+		}
+		return contracts
+	}
+
+	locations := func(levels [][]*Contract) [][]string {
+		result := make([][]string, len(levels))
+		for i, level := range levels {
+			for _, contract := range level {
+				result[i] = append(result[i], contract.Location())
+			}
+		}
+		return result
+	}
+
+	t.Run("Independent contracts", func(t *testing.T) {
+		deployment, err := NewDeployment(newContracts(testContractA, testContractB), nil)
+		require.NoError(t, err)
+
+		levels, err := deployment.Levels()
+		require.NoError(t, err)
+		result := locations(levels)
+		require.Len(t, result, 1)
+		assert.ElementsMatch(t, []string{"ContractA.cdc", "ContractB.cdc"}, result[0])
+	})
+
+	t.Run("Dependent contracts", func(t *testing.T) {
+		deployment, err := NewDeployment(
+			newContracts(testContractD, testContractG, testContractC, testContractB, testContractA),
+			nil,
+		)
+		require.NoError(t, err)
+
+		levels, err := deployment.Levels()
+		require.NoError(t, err)
+		// the order of contracts in the same level is not defined
+		result := locations(levels)
+		require.Len(t, result, 3)
+		assert.ElementsMatch(t, []string{"ContractA.cdc", "ContractB.cdc"}, result[0])
+		assert.ElementsMatch(t, []string{"ContractC.cdc", "ContractG.cdc"}, result[1])
+		assert.ElementsMatch(t, []string{"ContractD.cdc"}, result[2])
+
+		assert.Equal(t, []string{"ContractA", "ContractB"}, deployment.Dependencies("ContractG"))
+		assert.Equal(t, []string{"ContractC"}, deployment.Dependencies("ContractD"))
+		assert.Empty(t, deployment.Dependencies("ContractA"))
+	})
+
+	t.Run("No contracts", func(t *testing.T) {
+		deployment, err := NewDeployment(nil, nil)
+		require.NoError(t, err)
+
+		levels, err := deployment.Levels()
+		require.NoError(t, err)
+		assert.Len(t, levels, 0)
+	})
+
+	t.Run("Fail import cycle", func(t *testing.T) {
+		deployment, err := NewDeployment(newContracts(testContractE, testContractF), nil)
+		require.NoError(t, err)
+
+		_, err = deployment.Levels()
+		assert.IsType(t, &CyclicImportError{}, err)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-go-sdk"
 )
 
 type Program struct {
@@ -67,6 +68,37 @@ func (p *Program) imports() []string {
 	return imports
 }
 
+// AddressImports returns the addresses of all the imports that look like "import X from 0x01".
+func (p *Program) AddressImports() []flow.Address {
+	addresses := make([]flow.Address, 0)
+
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		location, isAddressImport := importDeclaration.Location.(common.AddressLocation)
+		if isAddressImport {
+			addresses = append(addresses, flow.BytesToAddress(location.Address.Bytes()))
+		}
+	}
+
+	return addresses
+}
+
+// ContractImports returns the address of each contract imported from an address, by the imported contract name.
+func (p *Program) ContractImports() map[string]flow.Address {
+	contracts := make(map[string]flow.Address)
+
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		location, isAddressImport := importDeclaration.Location.(common.AddressLocation)
+		if !isAddressImport {
+			continue
+		}
+		for _, identifier := range importDeclaration.Identifiers {
+			contracts[identifier.Identifier] = flow.BytesToAddress(location.Address.Bytes())
+		}
+	}
+
+	return contracts
+}
+
 func (p *Program) HasImports() bool {
 	return len(p.imports()) > 0
 }
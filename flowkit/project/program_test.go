@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -77,6 +78,28 @@ func TestProgram(t *testing.T) {
 		}
 	})
 
+	t.Run("Address imports", func(t *testing.T) {
+		program, err := NewProgram([]byte(`
+			import "Bar"
+			import Zoo from "./Zoo.cdc"
+			import FungibleToken from 0xee82856bf20e2aa6
+			import Foo from 0x01
+
+			pub contract Foo {}
+		`), nil, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.Address{
+			flow.HexToAddress("0xee82856bf20e2aa6"),
+			flow.HexToAddress("0x01"),
+		}, program.AddressImports())
+
+		assert.Equal(t, map[string]flow.Address{
+			"FungibleToken": flow.HexToAddress("0xee82856bf20e2aa6"),
+			"Foo":           flow.HexToAddress("0x01"),
+		}, program.ContractImports())
+	})
+
 	t.Run("Name", func(t *testing.T) {
 		tests := []struct {
 			code []byte
@@ -0,0 +1,171 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// UpdateIssue is a problem found by checking a contract update.
+//
+// Issues which are not warnings break the contract update rules and the network rejects the update.
+type UpdateIssue struct {
+	Message string
+	// Line is the line in the new code the issue refers to, or zero if it doesn't refer to a line.
+	Line    int
+	Warning bool
+}
+
+func (i UpdateIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// UpdateIssues are the issues found by checking a contract update.
+type UpdateIssues []UpdateIssue
+
+// Errors returns the issues that break the contract update rules.
+func (u UpdateIssues) Errors() UpdateIssues {
+	errs := make(UpdateIssues, 0)
+	for _, issue := range u {
+		if !issue.Warning {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+// Warnings returns the issues which don't prevent updating the contract.
+func (u UpdateIssues) Warnings() UpdateIssues {
+	warnings := make(UpdateIssues, 0)
+	for _, issue := range u {
+		if issue.Warning {
+			warnings = append(warnings, issue)
+		}
+	}
+	return warnings
+}
+
+// CheckContractUpdate checks whether the program can update the existing contract code deployed on the address.
+//
+// The existing and the new code are parsed with the Cadence parser and validated with the same contract update rules
+// the network uses, reporting for example changed field types, new fields, removed declarations and changed
+// conformances. Fields removed from the contract are reported as warnings, since the data stored in them can't be
+// accessed anymore.
+func CheckContractUpdate(address flow.Address, existing []byte, program *Program) UpdateIssues {
+	oldProgram, err := parser.ParseProgram(nil, existing, parser.Config{})
+	if err != nil {
+		return UpdateIssues{{
+			Message: fmt.Sprintf("existing contract code can't be parsed, the update can't be checked: %s", err),
+			Warning: true,
+		}}
+	}
+
+	name, err := program.Name()
+	if err != nil {
+		return UpdateIssues{{Message: err.Error()}}
+	}
+
+	location := common.NewAddressLocation(nil, common.Address(address), name)
+	issues := removedFields(oldProgram, program.astProgram)
+
+	err = stdlib.NewContractUpdateValidator(location, name, oldProgram, program.astProgram).Validate()
+	if updateErr, ok := err.(*stdlib.ContractUpdateError); ok {
+		for _, childErr := range updateErr.ChildErrors() {
+			issues = append(issues, newUpdateIssue(childErr))
+		}
+	} else if err != nil {
+		issues = append(issues, newUpdateIssue(err))
+	}
+
+	return issues
+}
+
+func newUpdateIssue(err error) UpdateIssue {
+	message := err.Error()
+	if secondary, ok := err.(errors.SecondaryError); ok {
+		message = fmt.Sprintf("%s: %s", message, secondary.SecondaryError())
+	}
+
+	issue := UpdateIssue{Message: message}
+	if positioned, ok := err.(ast.HasPosition); ok {
+		issue.Line = positioned.StartPosition().Line
+	}
+	return issue
+}
+
+// removedFields reports the fields of the existing contract and its nested declarations which are missing in the new code.
+func removedFields(oldProgram *ast.Program, newProgram *ast.Program) UpdateIssues {
+	oldDecl := rootDeclaration(oldProgram)
+	newDecl := rootDeclaration(newProgram)
+	if oldDecl == nil || newDecl == nil {
+		return nil
+	}
+
+	issues := make(UpdateIssues, 0)
+	var compare func(path []string, oldDecl ast.Declaration, newDecl ast.Declaration)
+	compare = func(path []string, oldDecl ast.Declaration, newDecl ast.Declaration) {
+		path = append(path, oldDecl.DeclarationIdentifier().Identifier)
+
+		newFields := newDecl.DeclarationMembers().FieldsByIdentifier()
+		for _, field := range oldDecl.DeclarationMembers().Fields() {
+			if _, ok := newFields[field.Identifier.Identifier]; !ok {
+				issues = append(issues, UpdateIssue{
+					Message: fmt.Sprintf(
+						"field `%s` was removed from `%s`, the data stored in it can't be accessed anymore",
+						field.Identifier.Identifier,
+						strings.Join(path, "."),
+					),
+					Warning: true,
+				})
+			}
+		}
+
+		// nested declarations missing in the new code are reported by the update validator
+		newComposites := newDecl.DeclarationMembers().CompositesByIdentifier()
+		for _, nested := range oldDecl.DeclarationMembers().Composites() {
+			if newNested, ok := newComposites[nested.Identifier.Identifier]; ok {
+				compare(path, nested, newNested)
+			}
+		}
+	}
+	compare(nil, oldDecl, newDecl)
+
+	return issues
+}
+
+func rootDeclaration(program *ast.Program) ast.Declaration {
+	if decl := program.SoleContractDeclaration(); decl != nil {
+		return decl
+	}
+	if decl := program.SoleContractInterfaceDeclaration(); decl != nil {
+		return decl
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckContractUpdate(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	existing := []byte(`
+		pub contract Foo {
+			pub let name: String
+			pub let count: Int
+
+			pub resource Vault {
+				pub var balance: UFix64
+				init() { self.balance = 0.0 }
+			}
+
+			init() {
+				self.name = "foo"
+				self.count = 0
+			}
+		}
+	`)
+
+	check := func(t *testing.T, code string) UpdateIssues {
+		program, err := NewProgram([]byte(code), nil, "Foo.cdc")
+		require.NoError(t, err)
+		return CheckContractUpdate(address, existing, program)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		issues := check(t, `
+			pub contract Foo {
+				pub let name: String
+				pub let count: Int
+
+				pub resource Vault {
+					pub var balance: UFix64
+					init() { self.balance = 0.0 }
+				}
+
+				pub fun hello(): String { return self.name }
+
+				init() {
+					self.name = "foo"
+					self.count = 0
+				}
+			}
+		`)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("Field Type Changed", func(t *testing.T) {
+		issues := check(t, `
+			pub contract Foo {
+				pub let name: String
+				pub let count: UInt64
+
+				pub resource Vault {
+					pub var balance: UFix64
+					init() { self.balance = 0.0 }
+				}
+
+				init() {
+					self.name = "foo"
+					self.count = 0
+				}
+			}
+		`)
+		require.Len(t, issues.Errors(), 1)
+		assert.Contains(t, issues[0].Message, "mismatching field `count` in `Foo`")
+		assert.Equal(t, 4, issues[0].Line)
+	})
+
+	t.Run("Field Added", func(t *testing.T) {
+		issues := check(t, `
+			pub contract Foo {
+				pub let name: String
+				pub let count: Int
+
+				pub resource Vault {
+					pub var balance: UFix64
+					pub var owner: Address?
+					init() {
+						self.balance = 0.0
+						self.owner = nil
+					}
+				}
+
+				init() {
+					self.name = "foo"
+					self.count = 0
+				}
+			}
+		`)
+		require.Len(t, issues.Errors(), 1)
+		assert.Contains(t, issues[0].String(), "found new field `owner` in `Vault`")
+	})
+
+	t.Run("Field and Declaration Removed", func(t *testing.T) {
+		issues := check(t, `
+			pub contract Foo {
+				pub let name: String
+
+				init() {
+					self.name = "foo"
+				}
+			}
+		`)
+		require.Len(t, issues.Warnings(), 1)
+		assert.Contains(t, issues.Warnings()[0].Message, "field `count` was removed from `Foo`")
+		require.Len(t, issues.Errors(), 1)
+		assert.Contains(t, issues.Errors()[0].Message, "declaration `Vault`")
+	})
+
+	t.Run("Existing Code Invalid", func(t *testing.T) {
+		program, err := NewProgram([]byte(`pub contract Foo {}`), nil, "Foo.cdc")
+		require.NoError(t, err)
+
+		issues := CheckContractUpdate(address, []byte(`pub contract Foo {`), program)
+		require.Len(t, issues, 1)
+		assert.True(t, issues[0].Warning)
+	})
+}
@@ -41,9 +41,16 @@ type Services interface {
 	Gateway() gateway.Gateway
 	SetLogger(output.Logger)
 
+	// SetWaitStrategy defines how long to wait for the results of sent transactions,
+	// by default waiting for the transactions to be sealed.
+	SetWaitStrategy(WaitStrategy)
+
 	// GetAccount fetches account on the Flow network.
 	GetAccount(context.Context, flow.Address) (*flow.Account, error)
 
+	// GetAccountAtBlockHeight fetches account on the Flow network as it was at the block height.
+	GetAccountAtBlockHeight(context.Context, flow.Address, uint64) (*flow.Account, error)
+
 	// CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
 	// Returns the newly created account as well as the ID of the transaction that created the account.
 	//
@@ -55,6 +62,9 @@ type Services interface {
 	// If the contract already exists on the account the operation will fail and error will be returned.
 	// Use UpdateExistingContract(bool) to define whether a contract should be updated or not, or you can also
 	// define a custom UpdateContract function which returns bool indicating whether a contract should be updated or not.
+	//
+	// Updates are checked against the contract update rules before they are sent, and an InvalidContractUpdateError
+	// is returned listing the violations if the network would reject the update.
 	AddContract(context.Context, *accounts.Account, Script, UpdateContract) (flow.Identifier, bool, error)
 
 	// RemoveContract from the provided account by its name.
@@ -76,6 +86,13 @@ type Services interface {
 	// if not provided only a single worker will be used.
 	GetEvents(context.Context, []string, uint64, uint64, *EventWorker) ([]flow.BlockEvents, error)
 
+	// SubscribeEvents from Flow network by their event names, starting at the start height and following new sealed blocks.
+	// If the start height is zero only events from blocks sealed after subscribing are delivered.
+	//
+	// Events of each block are delivered in order on the returned channel until the context is cancelled or fetching fails,
+	// in which case the error is sent on the error channel. The optional worker is used to fetch events the same way as in GetEvents.
+	SubscribeEvents(context.Context, []string, uint64, *EventWorker) (<-chan flow.BlockEvents, <-chan error, error)
+
 	// GenerateKey using the signature algorithm and optional seed. If seed is not provided a random safe seed will be generated.
 	GenerateKey(context.Context, crypto.SignatureAlgorithm, string) (crypto.PrivateKey, error)
 
@@ -91,13 +108,34 @@ type Services interface {
 	// Retrieve all the contracts for specified network, sort them for deployment deploy one by one and replace
 	// the imports in the contract source, so it corresponds to the account name the contract was deployed to.
 	// If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
-	DeployProject(context.Context, UpdateContract) ([]*project.Contract, error)
+	//
+	// Options can be provided to deploy independent contracts concurrently using WithDeployConcurrency,
+	// and to skip checking contract updates against the update rules using WithoutUpdateCheck.
+	//
+	// Deployed contracts are recorded per network in the project lockfile, and contracts unchanged since they were
+	// last deployed are skipped without fetching the target account, unless WithIgnoreLockfile is provided.
+	DeployProject(context.Context, UpdateContract, ...DeployOption) ([]*project.Contract, error)
+
+	// PlanDeployment returns the plan for deploying the project contracts without sending any transactions.
+	//
+	// Each contract is returned in deployment order with the action deploying it would take, either adding, updating or
+	// leaving the contract unchanged, or being blocked if the contract exists and the UpdateContract function returns false
+	// or the update breaks the contract update rules, in which case the plan includes the update issues.
+	// The plan includes the addresses the imports resolve to and the existing code on the target account.
+	PlanDeployment(context.Context, UpdateContract) ([]ContractPlan, error)
+
+	// DeploymentStatus compares the project deployment contracts with the lockfile and the code on the network.
+	//
+	// The status of each contract reports whether it's recorded in the lockfile, whether its local source changed
+	// since it was deployed and whether the code on the network still matches the deployed code.
+	DeploymentStatus(context.Context) ([]ContractStatus, error)
 
 	// ExecuteScript on the Flow network and return the Cadence value as a result. The script is executed at the
 	// block provided as part of the ScriptQuery value.
 	ExecuteScript(context.Context, Script, ScriptQuery) (cadence.Value, error)
 
-	// GetTransactionByID from the Flow network including the transaction result. Using the waitSeal we can wait for the transaction to be sealed.
+	// GetTransactionByID from the Flow network including the transaction result. Using the wait we can wait for the transaction
+	// to reach the status defined by the wait strategy.
 	GetTransactionByID(context.Context, flow.Identifier, bool) (*flow.Transaction, *flow.TransactionResult, error)
 
 	GetTransactionsByBlockID(context.Context, flow.Identifier) ([]*flow.Transaction, []*flow.TransactionResult, error)
@@ -120,4 +158,16 @@ type Services interface {
 	// SendTransaction will build and send a transaction to the Flow network, using the accounts provided for each role and
 	// contain the script. Transaction as well as transaction result will be returned in case the transaction is successfully submitted.
 	SendTransaction(context.Context, transactions.AccountRoles, Script, uint64) (*flow.Transaction, *flow.TransactionResult, error)
+
+	// SendTransactions sends the transactions concurrently, proposing each transaction with a key from a key pool
+	// of the proposer account so the transactions don't conflict on sequence numbers. Results are returned in order.
+	SendTransactions(context.Context, []BatchTransaction, int) ([]BatchResult, error)
+
+	// SimulateTransaction builds the transaction like SendTransaction but executes it in an in-process emulator seeded
+	// with the accounts the transaction uses, so the result can be inspected without submitting the transaction to the network.
+	SimulateTransaction(context.Context, transactions.AccountRoles, Script, uint64) (*flow.Transaction, *gateway.SimulationResult, error)
+
+	// EstimateTransaction simulates the transaction to measure the computation it uses and the fee charged for it,
+	// which can be used to set the gas limit of the transaction.
+	EstimateTransaction(context.Context, transactions.AccountRoles, Script) (*TransactionEstimate, error)
 }
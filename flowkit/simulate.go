@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/fvm/environment"
+	flowGo "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// SimulateTransaction builds the transaction and executes it in an in-process emulator without submitting it to the network.
+//
+// The emulator is seeded with the accounts the transaction uses, which are the accounts of each role and the accounts
+// of imported contracts including their imports. The transaction is not signed, since signatures are not validated.
+// An error is returned if any of the accounts can't be created in the emulator at its network address.
+func (f *Flowkit) SimulateTransaction(
+	ctx context.Context,
+	accounts transactions.AccountRoles,
+	script Script,
+	gasLimit uint64,
+) (*flow.Transaction, *gateway.SimulationResult, error) {
+	tx, err := f.BuildTransaction(
+		ctx,
+		accounts.AddressRoles(),
+		accounts.Proposer.Key.Index(),
+		script,
+		gasLimit,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	flowTx := tx.FlowTransaction()
+
+	chainID, err := gateway.ChainForAddress(flowTx.Payer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f.logger.StartProgress("Simulating transaction...")
+	defer f.logger.StopProgress()
+
+	simulator := gateway.NewSimulatorGateway(chainID)
+	seed, err := f.simulationAccounts(ctx, simulator, flowTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := simulator.SeedAccounts(ctx, seed); err != nil {
+		return nil, nil, fmt.Errorf("failed to seed simulator: %w", err)
+	}
+
+	result, err := simulator.SimulateTransaction(ctx, flowTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return flowTx, result, nil
+}
+
+// simulationAccounts fetches the accounts used by the transaction that don't exist in the simulator.
+func (f *Flowkit) simulationAccounts(
+	ctx context.Context,
+	simulator *gateway.SimulatorGateway,
+	tx *flow.Transaction,
+) ([]*flow.Account, error) {
+	pending := append([]flow.Address{tx.ProposalKey.Address, tx.Payer}, tx.Authorizers...)
+	if program, err := project.NewProgram(tx.Script, nil, ""); err == nil {
+		pending = append(pending, program.AddressImports()...)
+	}
+
+	seen := make(map[flow.Address]bool)
+	accounts := make([]*flow.Account, 0)
+	for len(pending) > 0 {
+		address := pending[0]
+		pending = pending[1:]
+
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		if _, err := simulator.GetAccount(ctx, address); err == nil {
+			continue // core contract accounts already exist in the simulator
+		}
+
+		account, err := f.gateway.GetAccount(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account %s for simulation: %w", address, err)
+		}
+		accounts = append(accounts, account)
+
+		for _, code := range account.Contracts {
+			program, err := project.NewProgram(code, nil, "")
+			if err != nil {
+				continue
+			}
+			pending = append(pending, program.AddressImports()...)
+		}
+	}
+
+	return accounts, nil
+}
+
+// TransactionEstimate contains the computation used by a simulated transaction and the fee charged for it.
+type TransactionEstimate struct {
+	ComputationUsed uint64
+	Fee             cadence.UFix64
+}
+
+const computeFeesScript = `
+import FlowFees from 0x%s
+
+pub fun main(inclusionEffort: UFix64, executionEffort: UFix64): UFix64 {
+	return FlowFees.computeFees(inclusionEffort: inclusionEffort, executionEffort: executionEffort)
+}`
+
+// EstimateTransaction simulates the transaction with the maximum gas limit to measure the computation it uses,
+// and computes the fee for it using the fee parameters of the network.
+//
+// The estimate is only as accurate as the simulation, the storage of the accounts is not copied to the simulator,
+// so transactions reading it might use different computation than they do on the network.
+func (f *Flowkit) EstimateTransaction(
+	ctx context.Context,
+	accounts transactions.AccountRoles,
+	script Script,
+) (*TransactionEstimate, error) {
+	tx, simulation, err := f.SimulateTransaction(ctx, accounts, script, flow.DefaultTransactionGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if simulation.Result.Error != nil {
+		return nil, fmt.Errorf("failed to estimate transaction, simulation failed: %w", simulation.Result.Error)
+	}
+
+	chainID, err := gateway.ChainForAddress(tx.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	feesAddress := environment.FlowFeesAddress(flowGo.ChainID(chainID).Chain())
+	fee, err := f.gateway.ExecuteScript(
+		ctx,
+		[]byte(fmt.Sprintf(computeFeesScript, feesAddress.Hex())),
+		[]cadence.Value{
+			cadence.UFix64(flowGo.TransactionBody{}.InclusionEffort()),
+			cadence.UFix64(simulation.ComputationUsed),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute transaction fee: %w", err)
+	}
+
+	value, ok := fee.(cadence.UFix64)
+	if !ok {
+		return nil, fmt.Errorf("failed to compute transaction fee, unexpected result %s", fee)
+	}
+
+	return &TransactionEstimate{
+		ComputationUsed: simulation.ComputationUsed,
+		Fee:             value,
+	}, nil
+}
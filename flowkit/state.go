@@ -221,6 +221,31 @@ func (p *State) AliasesForNetwork(network config.Network) project.LocationAliase
 	return aliases
 }
 
+// NetworkWithResolvedPaths returns a copy of the network with the TLS files relative to the configuration file,
+// so the network can be used when the configuration is loaded from a different directory.
+func (p *State) NetworkWithResolvedPaths(network config.Network) config.Network {
+	if !network.TLS.IsEnabled() {
+		return network
+	}
+
+	tls := *network.TLS
+	tls.CAFile = p.configRelativePath(tls.CAFile)
+	tls.CertFile = p.configRelativePath(tls.CertFile)
+	tls.KeyFile = p.configRelativePath(tls.KeyFile)
+	network.TLS = &tls
+
+	return network
+}
+
+// configRelativePath joins the relative location with the directory of the configuration, if it was loaded from a single file.
+func (p *State) configRelativePath(location string) string {
+	if location == "" || filepath.IsAbs(location) || p.confLoader == nil || len(p.confLoader.LoadedLocations) != 1 {
+		return location
+	}
+
+	return filepath.Join(filepath.Dir(p.confLoader.LoadedLocations[0]), location)
+}
+
 // Load loads a project configuration and returns the resulting project.
 func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
 	confLoader := config.NewLoader(readerWriter)
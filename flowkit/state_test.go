@@ -21,6 +21,7 @@ package flowkit
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -593,6 +594,35 @@ func Test_LoadState(t *testing.T) {
 	assert.Equal(t, acc.Name, "emulator-account")
 }
 
+func Test_NetworkWithResolvedPaths(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"mainnet": {
+				"host": "access.mainnet.nodes.onflow.org:9000",
+				"tls": {"caFile": "./certs/ca.pem", "certFile": "/etc/certs/client.pem", "keyFile": "/etc/certs/client.key"}
+			}
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := afero.WriteFile(af.Fs, "project/flow.json", b, 0644)
+	require.NoError(t, err)
+
+	state, err := Load([]string{"project/flow.json"}, af)
+	require.NoError(t, err)
+
+	mainnet, err := state.Networks().ByName("mainnet")
+	require.NoError(t, err)
+
+	resolved := state.NetworkWithResolvedPaths(*mainnet)
+	assert.Equal(t, filepath.Join("project", "certs", "ca.pem"), resolved.TLS.CAFile)
+	assert.Equal(t, "/etc/certs/client.pem", resolved.TLS.CertFile)
+	assert.Equal(t, "/etc/certs/client.key", resolved.TLS.KeyFile)
+
+	// the configuration is not changed, so it's saved with the original paths
+	assert.Equal(t, "./certs/ca.pem", mainnet.TLS.CAFile)
+}
+
 func Test_LoadStateMultiple(t *testing.T) {
 	b := []byte(`{
 		"accounts": {
@@ -887,3 +917,50 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 	assert.Equal(t, state.conf, &cfg)
 	assert.NoError(t, err)
 }
+
+func Test_Lockfile(t *testing.T) {
+	b := []byte(`{
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := afero.WriteFile(af.Fs, "project/flow.json", b, 0644)
+	require.NoError(t, err)
+
+	state, err := Load([]string{"project/flow.json"}, af)
+	require.NoError(t, err)
+	assert.Equal(t, "project/flow.lock", state.LockfilePath())
+
+	lock, err := state.Lockfile()
+	require.NoError(t, err)
+	assert.Empty(t, lock.Contracts("emulator"))
+
+	locked := LockedContract{
+		Account:       "emulator-account",
+		Address:       "0xf8d6e0586b0a20c7",
+		SourceHash:    HashCode([]byte("source")),
+		CodeHash:      HashCode([]byte("code")),
+		TransactionID: "abc",
+		BlockHeight:   10,
+		Imports:       map[string]string{"Foo": "0x01"},
+	}
+	lock.SetContract("emulator", "Hello", locked)
+	require.NoError(t, state.SaveLockfile(lock))
+
+	lock, err = state.Lockfile()
+	require.NoError(t, err)
+	loaded, ok := lock.Contract("emulator", "Hello")
+	assert.True(t, ok)
+	assert.Equal(t, locked, loaded)
+
+	lock.RemoveContract("emulator", "Hello")
+	assert.Empty(t, lock.Contracts("emulator"))
+
+	_, err = ParseLockfile([]byte(`{"version": 2, "networks": {}}`))
+	assert.EqualError(t, err, "unsupported lockfile version 2")
+}
@@ -0,0 +1,157 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ContractStatus describes how a contract differs between the lockfile, the local sources and the network.
+type ContractStatus struct {
+	Name    string
+	Account string
+	Address flow.Address
+	// Locked is the lockfile entry of the contract, nil if the contract isn't recorded in the lockfile.
+	Locked *LockedContract
+	// Configured is false for contracts recorded in the lockfile which are no longer part of the project deployment.
+	Configured bool
+	// LocalChanged is set when the contract source, its resolved imports or its account changed since it was deployed.
+	LocalChanged bool
+	// OnChain is set when the contract exists on the account it was deployed to.
+	OnChain bool
+	// OnChainChanged is set when the code on the account differs from the code recorded in the lockfile.
+	OnChainChanged bool
+}
+
+// Drifted returns whether the lockfile, the local sources and the network don't all agree on the contract.
+func (s ContractStatus) Drifted() bool {
+	return s.Locked == nil || !s.Configured || s.LocalChanged || !s.OnChain || s.OnChainChanged
+}
+
+// DeploymentStatus compares the contracts of the project deployment with the lockfile and the code on the network.
+//
+// Contracts recorded in the lockfile are checked on the account they were deployed to, followed by the contracts
+// which are recorded in the lockfile but no longer part of the deployment.
+func (f *Flowkit) DeploymentStatus(ctx context.Context) ([]ContractStatus, error) {
+	state, err := f.State()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(f.network)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := state.Lockfile()
+	if err != nil {
+		return nil, err
+	}
+
+	f.logger.StartProgress(fmt.Sprintf("Checking status of %d contracts...", len(contracts)))
+	defer f.logger.StopProgress()
+
+	// each account is fetched only once, since it usually contains more than one contract
+	flowAccounts := make(map[flow.Address]*flow.Account)
+	onChainCode := func(address flow.Address, name string) ([]byte, bool, error) {
+		flowAccount, ok := flowAccounts[address]
+		if !ok {
+			account, err := f.gateway.GetAccount(ctx, address)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get account %s: %w", address, err)
+			}
+			flowAccount = account
+			flowAccounts[address] = flowAccount
+		}
+
+		code, exists := flowAccount.Contracts[name]
+		return code, exists, nil
+	}
+
+	locked := lock.Contracts(f.network.Name)
+	statuses := make([]ContractStatus, 0, len(contracts))
+	for _, contract := range contracts {
+		program, name, err := f.resolveContract(
+			state,
+			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve contract %s: %w", contract.Name, err)
+		}
+
+		status := ContractStatus{
+			Name:       contract.Name,
+			Account:    contract.AccountName,
+			Address:    contract.AccountAddress,
+			Configured: true,
+		}
+
+		address := contract.AccountAddress
+		if entry, ok := locked[contract.Name]; ok {
+			current := newLockedContract(contract, program)
+			status.Locked = &entry
+			status.LocalChanged = entry.SourceHash != current.SourceHash ||
+				entry.CodeHash != current.CodeHash ||
+				entry.Address != current.Address
+			// the contract is checked where it was deployed, even if it's now configured for another account
+			address = flow.HexToAddress(entry.Address)
+			delete(locked, contract.Name)
+		}
+
+		code, exists, err := onChainCode(address, name)
+		if err != nil {
+			return nil, err
+		}
+		status.OnChain = exists
+		status.OnChainChanged = exists && status.Locked != nil && HashCode(code) != status.Locked.CodeHash
+
+		statuses = append(statuses, status)
+	}
+
+	names := make([]string, 0, len(locked))
+	for name := range locked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := locked[name]
+		address := flow.HexToAddress(entry.Address)
+
+		code, exists, err := onChainCode(address, name)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, ContractStatus{
+			Name:           name,
+			Account:        entry.Account,
+			Address:        address,
+			Locked:         &entry,
+			OnChain:        exists,
+			OnChainChanged: exists && HashCode(code) != entry.CodeHash,
+		})
+	}
+
+	return statuses, nil
+}
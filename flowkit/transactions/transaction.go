@@ -258,6 +258,14 @@ func (t *Transaction) SetProposer(proposer *flow.Account, keyIndex int) error {
 	return nil
 }
 
+// SetProposalKey sets the proposal key for transaction using the provided key index and sequence number.
+//
+// Unlike SetProposer the proposer account is not required, which is useful when sequence numbers are tracked by the caller.
+func (t *Transaction) SetProposalKey(address flow.Address, keyIndex int, sequenceNumber uint64) *Transaction {
+	t.tx.SetProposalKey(address, keyIndex, sequenceNumber)
+	return t
+}
+
 // SetPayer sets the payer for transaction.
 func (t *Transaction) SetPayer(address flow.Address) *Transaction {
 	t.tx.SetPayer(address)
@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// InvalidContractUpdateError is returned when updating a contract would break the contract update rules.
+type InvalidContractUpdateError struct {
+	Contract string
+	Issues   project.UpdateIssues
+}
+
+func (e *InvalidContractUpdateError) Error() string {
+	lines := []string{fmt.Sprintf("contract %s can't be updated:", e.Contract)}
+	for _, issue := range e.Issues {
+		lines = append(lines, fmt.Sprintf("  - %s", issue))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkContractUpdate checks the update of the existing contract before it's sent to the network,
+// logging the warnings and returning an error if the update breaks the contract update rules.
+func (f *Flowkit) checkContractUpdate(address flow.Address, name string, existing []byte, program *project.Program) error {
+	issues := project.CheckContractUpdate(address, existing, program)
+
+	for _, warning := range issues.Warnings() {
+		f.logger.Info(fmt.Sprintf("%s Contract %s update: %s", output.WarningEmoji(), name, warning))
+	}
+
+	if errs := issues.Errors(); len(errs) > 0 {
+		return &InvalidContractUpdateError{Contract: name, Issues: errs}
+	}
+	return nil
+}
@@ -264,6 +264,23 @@ func Test_Get(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+
+	t.Run("Success at block height", func(t *testing.T) {
+		inArgs := []string{"0x01"}
+		getFlags.BlockHeight = 100
+
+		srv.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+			assert.Equal(t, "0000000000000001", args.Get(1).(flow.Address).String())
+			assert.Equal(t, uint64(100), args.Get(2).(uint64))
+			srv.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(inArgs[0]), nil)
+		})
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		srv.Mock.AssertCalled(t, "GetAccountAtBlockHeight", mock.Anything, flow.HexToAddress("0x01"), uint64(100))
+		getFlags.BlockHeight = 0 // reset
+	})
 }
 
 func Test_Result(t *testing.T) {
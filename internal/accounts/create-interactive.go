@@ -69,7 +69,7 @@ func createInteractive(state *flowkit.State) error {
 	log.StartProgress(fmt.Sprintf("Creating account %s on %s...", name, networkName))
 
 	var account *accounts.Account
-	if selectedNetwork == config.EmulatorNetwork {
+	if selectedNetwork.Name == config.EmulatorNetwork.Name {
 		account, err = createEmulatorAccount(state, flow, name, key)
 		log.StopProgress()
 		log.Info(output.Italic("\nPlease note that the newly-created account will only be available while you keep the emulator service running. If you restart the emulator service, all accounts will be reset. If you want to persist accounts between restarts, please use the '--persist' flag when starting the flow emulator.\n"))
@@ -99,7 +99,7 @@ func createInteractive(state *flowkit.State) error {
 		"Here’s a summary of all the actions that were taken",
 		fmt.Sprintf("Added the new account to %s.", output.Bold("flow.json")),
 	}
-	if selectedNetwork != config.EmulatorNetwork {
+	if selectedNetwork.Name != config.EmulatorNetwork.Name {
 		items = append(items,
 			fmt.Sprintf("Saved the private key to %s.", output.Bold(privateFile)),
 			fmt.Sprintf("Added %s to %s.", output.Bold(privateFile), output.Bold(".gitignore")),
@@ -31,17 +31,21 @@ import (
 )
 
 type flagsGet struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	BlockHeight uint64   `default:"" flag:"block-height" info:"Block height at which to get the account, the latest block is used if not set"`
 }
 
 var getFlags = flagsGet{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <address>",
-		Short:   "Gets an account by address",
-		Example: "flow accounts get f8d6e0586b0a20c7",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <address>",
+		Short: "Gets an account by address",
+		Example: `flow accounts get f8d6e0586b0a20c7
+
+#get the account as it was at an earlier block height
+flow accounts get f8d6e0586b0a20c7 --block-height 11559500`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &getFlags,
 	Run:   get,
@@ -59,7 +63,13 @@ func get(
 	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
 	defer logger.StopProgress()
 
-	account, err := flow.GetAccount(context.Background(), address)
+	var account *flowsdk.Account
+	var err error
+	if getFlags.BlockHeight > 0 {
+		account, err = flow.GetAccountAtBlockHeight(context.Background(), address, getFlags.BlockHeight)
+	} else {
+		account, err = flow.GetAccount(context.Background(), address)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -24,14 +24,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/user"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/dukex/mixpanel"
 	"github.com/getsentry/sentry-go"
@@ -65,11 +69,26 @@ type RunWithState func(
 	state *flowkit.State,
 ) (Result, error)
 
+// NetworkServices creates the services for the network with the name from the configuration,
+// the gateway is created the same way as for the services commands are run with.
+type NetworkServices func(network string) (flowkit.Services, error)
+
+// RunWithNetworks runs the command with arguments and state on one or more networks,
+// the services for each network are created using the provided function.
+type RunWithNetworks func(
+	args []string,
+	globalFlags GlobalFlags,
+	logger output.Logger,
+	state *flowkit.State,
+	services NetworkServices,
+) (Result, error)
+
 type Command struct {
 	Cmd    *cobra.Command
 	Flags  any
 	Run    run
 	RunS   RunWithState
+	RunN   RunWithNetworks
 	Status *int
 }
 
@@ -109,16 +128,43 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			handleError("Config Error", confErr)
 		}
 
-		network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
-		handleError("Host Error", err)
+		logger := createLogger(Flags.Log, Flags.Format)
 
-		clientGateway, err := createGateway(*network)
-		handleError("Gateway Error", err)
+		var tracing *commandTracing
+		var err error
+		if Flags.Trace != "" {
+			tracing, err = startTracing(Flags.Trace, c.Cmd.CommandPath())
+			handleError("Trace Error", err)
+		}
 
-		logger := createLogger(Flags.Log, Flags.Format)
+		// spans are exported before handling an error, since handling it exits, so failed commands are traced as well
+		endTracing := func(err error) {
+			if tracing == nil {
+				return
+			}
+			if traceErr := tracing.end(err); traceErr != nil {
+				logger.Error(fmt.Sprintf("Failed to export the trace: %s", traceErr))
+			}
+			tracing = nil
+		}
+		handleTracedError := func(description string, err error) {
+			if err != nil {
+				endTracing(err)
+			}
+			handleError(description, err)
+		}
 
-		// initialize services
-		flow := flowkit.NewFlowkit(state, *network, clientGateway, logger)
+		// initialize services, commands running on several networks create them for each network
+		var flow flowkit.Services
+		if c.RunN == nil {
+			network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
+			handleTracedError("Host Error", err)
+
+			clientGateway, err := createGateway(*network, tracing)
+			handleTracedError("Gateway Error", err)
+
+			flow = flowkit.NewFlowkit(state, *network, clientGateway, logger)
+		}
 
 		// skip version check if flag is set
 		if !Flags.SkipVersionCheck {
@@ -135,14 +181,33 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			result, err = c.Run(args, Flags, logger, loader, flow)
 		} else if c.RunS != nil {
 			if confErr != nil {
-				handleError("Config Error", confErr)
+				handleTracedError("Config Error", confErr)
 			}
 
 			result, err = c.RunS(args, Flags, logger, flow, state)
+		} else if c.RunN != nil {
+			if confErr != nil {
+				handleTracedError("Config Error", confErr)
+			}
+
+			result, err = c.RunN(args, Flags, logger, state, func(name string) (flowkit.Services, error) {
+				network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, name)
+				if err != nil {
+					return nil, err
+				}
+
+				clientGateway, err := createGateway(*network, tracing)
+				if err != nil {
+					return nil, err
+				}
+
+				return flowkit.NewFlowkit(state, *network, clientGateway, logger), nil
+			})
 		} else {
 			panic("command implementation needs to provide run functionality")
 		}
 
+		endTracing(err)
 		handleError("Command Error", err)
 
 		// Do not print a result if none is provided.
@@ -169,15 +234,95 @@ func (c Command) AddToParent(parent *cobra.Command) {
 }
 
 // createGateway creates a gateway to be used, defaults to grpc but can support others.
-func createGateway(network config.Network) (gateway.Gateway, error) {
-	// create secure grpc client if hostNetworkKey provided
+//
+// If the network defines multiple endpoints the calls are distributed between them using the network endpoint policy,
+// and if tracing is provided the calls to each endpoint are traced.
+// The gateway is wrapped to limit the rate of calls if the network defines a rate limit, to retry transient access node
+// errors as defined by the network retry configuration and to cache immutable chain data, which is also persisted
+// on disk if the network cache configuration enables it for networks not running locally.
+func createGateway(network config.Network, tracing *commandTracing) (gateway.Gateway, error) {
+	hosts := network.Hosts()
+	gateways := make([]gateway.Gateway, len(hosts))
+	for i, host := range hosts {
+		endpoint := network
+		endpoint.Host = host
+
+		gw, err := createEndpointGateway(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if tracing != nil {
+			gw = gateway.NewInstrumentedGateway(gw, tracing.interceptors(host)...)
+		}
+		gateways[i] = gw
+	}
+
+	gw := gateways[0]
+	if len(gateways) > 1 {
+		var err error
+		gw, err = gateway.NewFailoverGateway(gateways, network.Policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the rate limit is applied before retrying, so retried calls are limited as well
+	if network.RateLimit.RequestsPerSecond > 0 {
+		gw = gateway.NewRateLimitGateway(gw, network.RateLimit)
+	}
+
+	gw = gateway.NewRetryGateway(gw, network.Retry)
+
+	// local networks such as the emulator can be restarted with a different chain so their data is only cached in memory
+	if !network.Cache.Disk || isLocalHost(network.Host) {
+		return gateway.NewCachingGateway(gw), nil
+	}
+
+	opts := []func(*gateway.CachingGateway){gateway.WithCacheDir(cacheDir(network))}
+	if network.Cache.MaxDiskSize > 0 {
+		opts = append(opts, gateway.WithMaxDiskSize(network.Cache.MaxDiskSize))
+	}
+
+	return gateway.NewCachingGateway(gw, opts...), nil
+}
+
+// createEndpointGateway creates a gateway for the network host.
+func createEndpointGateway(network config.Network) (gateway.Gateway, error) {
+	// use the REST API if the host is an http URL, otherwise create secure grpc client if hostNetworkKey provided,
+	// the grpc client uses the network TLS configuration if provided
+	if gateway.IsRestHost(network.Host) {
+		return gateway.NewRestGateway(network)
+	}
 	if network.Key != "" {
 		return gateway.NewSecureGrpcGateway(network)
 	}
-
 	return gateway.NewGrpcGateway(network)
 }
 
+// isLocalHost checks whether the host points to this machine.
+func isLocalHost(host string) bool {
+	hostname := host
+	if u, err := url.Parse(host); err == nil && gateway.IsRestHost(host) {
+		hostname = u.Hostname()
+	} else if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	return hostname == "localhost" || hostname == "0.0.0.0" || net.ParseIP(hostname).IsLoopback()
+}
+
+// cacheDir returns the directory in the settings dir where chain data for the network host is cached.
+func cacheDir(network config.Network) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, network.Host)
+
+	return filepath.Join(settings.FileDir(), "cache", name)
+}
+
 // resolveHost from the flags provided.
 //
 // Resolve the network host in the following order:
@@ -215,7 +360,9 @@ func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag str
 			return nil, fmt.Errorf("network with name %s does not exist in configuration", networkFlag)
 		}
 
-		return stateNetwork, nil
+		// files referenced by the network are relative to the configuration file
+		network := state.NetworkWithResolvedPaths(*stateNetwork)
+		return &network, nil
 	}
 
 	networks := config.DefaultNetworks
@@ -360,4 +507,5 @@ type GlobalFlags struct {
 	Yes              bool
 	ConfigPaths      []string
 	SkipVersionCheck bool
+	Trace            string
 }
@@ -41,6 +41,7 @@ var Flags = GlobalFlags{
 	Yes:              false,
 	ConfigPaths:      config.DefaultPaths(),
 	SkipVersionCheck: false,
+	Trace:            "",
 }
 
 // InitFlags init all the global persistent flags.
@@ -58,7 +59,7 @@ func InitFlags(cmd *cobra.Command) {
 		"host",
 		"",
 		Flags.Host,
-		"Flow Access API host address",
+		"Flow Access API host address, use an http(s) URL for the REST API",
 	)
 
 	cmd.PersistentFlags().StringVarP(
@@ -124,6 +125,14 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.SkipVersionCheck,
 		"Skip version check during start up",
 	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Trace,
+		"trace",
+		"",
+		Flags.Trace,
+		"Trace Access API calls to a JSON file, or to an OTLP collector using \"otlp://host:port\"",
+	)
 }
 
 // bindFlags bind all the flags needed.
@@ -80,6 +80,21 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 	}
 }
 
+// PrintResult outputs a single result of a command that outputs results continuously.
+//
+// Results are printed on a single line when using the JSON or inline format, so the output can be processed line by line.
+func PrintResult(result Result, formatFlag string) {
+	switch strings.ToLower(formatFlag) {
+	case formatJSON:
+		jsonRes, _ := json.Marshal(result.JSON())
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", jsonRes)
+	case formatInline:
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", result.Oneliner())
+	default:
+		_, _ = fmt.Fprintf(os.Stdout, "%s", result.String())
+	}
+}
+
 // outputResult to selected media.
 func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
 	if saveFlag != "" {
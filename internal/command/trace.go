@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-cli/build"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+// otlpPrefix is used in the trace flag to export spans to an OTLP collector instead of a file.
+const otlpPrefix = "otlp://"
+
+// commandTracing traces the gateway calls made by a command as children of the command span.
+type commandTracing struct {
+	provider *sdktrace.TracerProvider
+	span     trace.Span
+}
+
+// startTracing starts the command span, the spans are exported to the OTLP collector
+// if the destination is an otlp://host:port URL, otherwise they are written to the destination file as JSON.
+func startTracing(destination string, command string) (*commandTracing, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	if strings.HasPrefix(destination, otlpPrefix) {
+		exporter, err = otlptracegrpc.New(
+			context.Background(),
+			otlptracegrpc.WithEndpoint(strings.TrimPrefix(destination, otlpPrefix)),
+			otlptracegrpc.WithInsecure(),
+		)
+	} else {
+		exporter, err = newFileExporter(destination)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("flow-cli"),
+			semconv.ServiceVersion(build.Semver()),
+		)),
+	)
+
+	_, span := provider.Tracer(gateway.TracerName).Start(context.Background(), command)
+
+	return &commandTracing{
+		provider: provider,
+		span:     span,
+	}, nil
+}
+
+// interceptors return the gateway interceptors tracing the calls to the access node host.
+func (t *commandTracing) interceptors(host string) []gateway.Interceptor {
+	return []gateway.Interceptor{
+		t.withCommandSpan,
+		gateway.NewTracingInterceptor(t.provider.Tracer(gateway.TracerName), attribute.String("net.peer.name", host)),
+	}
+}
+
+// withCommandSpan sets the command span as the parent of calls without a span,
+// since commands don't pass the span in the context to flowkit.
+func (t *commandTracing) withCommandSpan(ctx context.Context, _ string, invoke func(context.Context) error) error {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithSpan(ctx, t.span)
+	}
+
+	return invoke(ctx)
+}
+
+// end ends the command span recording the command error and exports all the spans.
+func (t *commandTracing) end(err error) error {
+	if err != nil {
+		t.span.RecordError(err)
+		t.span.SetStatus(codes.Error, err.Error())
+	}
+	t.span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return t.provider.Shutdown(ctx)
+}
+
+var _ sdktrace.SpanExporter = &fileExporter{}
+
+// fileExporter writes the spans to a file as JSON, one span per line.
+type fileExporter struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newFileExporter(filename string) (*fileExporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+type jsonSpan struct {
+	Name       string         `json:"name"`
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	ParentID   string         `json:"parentId,omitempty"`
+	Start      time.Time      `json:"start"`
+	End        time.Time      `json:"end"`
+	Duration   string         `json:"duration"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+func (e *fileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, s := range spans {
+		span := jsonSpan{
+			Name:     s.Name(),
+			TraceID:  s.SpanContext().TraceID().String(),
+			SpanID:   s.SpanContext().SpanID().String(),
+			Start:    s.StartTime(),
+			End:      s.EndTime(),
+			Duration: s.EndTime().Sub(s.StartTime()).String(),
+		}
+		if s.Parent().IsValid() {
+			span.ParentID = s.Parent().SpanID().String()
+		}
+		if s.Status().Code == codes.Error {
+			span.Error = s.Status().Description
+		}
+		if len(s.Attributes()) > 0 {
+			span.Attributes = make(map[string]any, len(s.Attributes()))
+			for _, a := range s.Attributes() {
+				span.Attributes[string(a.Key)] = a.Value.AsInterface()
+			}
+		}
+
+		if err := e.encoder.Encode(span); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *fileExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file.Close()
+}
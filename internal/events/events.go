@@ -41,6 +41,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	followCommand.AddToParent(Cmd)
 }
 
 type EventResult struct {
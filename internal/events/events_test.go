@@ -20,6 +20,7 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -74,6 +75,48 @@ func Test_Get(t *testing.T) {
 
 }
 
+func Test_Follow(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		inArgs := []string{"test.event"}
+		followFlags.Start = 10
+
+		events := make(chan flow.BlockEvents, 1)
+		events <- flow.BlockEvents{Height: 10}
+		close(events)
+		errs := make(chan error)
+		close(errs)
+
+		srv.Mock.On("SubscribeEvents", mock.Anything, inArgs, uint64(10), mock.Anything).
+			Return((<-chan flow.BlockEvents)(events), (<-chan error)(errs), nil).
+			Once()
+
+		result, err := follow(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Fail subscription", func(t *testing.T) {
+		inArgs := []string{"test.event"}
+
+		events := make(chan flow.BlockEvents)
+		close(events)
+		errs := make(chan error, 1)
+		errs <- fmt.Errorf("failed getting events")
+		close(errs)
+
+		srv.Mock.On("SubscribeEvents", mock.Anything, inArgs, uint64(10), mock.Anything).
+			Return((<-chan flow.BlockEvents)(events), (<-chan error)(errs), nil).
+			Once()
+
+		result, err := follow(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed getting events")
+		assert.Nil(t, result)
+		followFlags.Start = 0 // reset
+	})
+}
+
 func Test_Result(t *testing.T) {
 	block := tests.NewBlock()
 	event := EventResult{
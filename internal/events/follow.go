@@ -0,0 +1,94 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsFollow struct {
+	Start   uint64 `flag:"start" info:"Block height to start following from, used to resume following. If not set only new blocks are followed"`
+	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+}
+
+var followFlags = flagsFollow{}
+
+var followCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "follow <event_name>",
+		Short: "Follow events in new sealed blocks",
+		Args:  cobra.MinimumNArgs(1),
+		Example: `#print events from new sealed blocks until interrupted
+flow events follow A.1654653399040a61.FlowToken.TokensDeposited --network mainnet
+
+#resume following from a block height, multiple event types can be followed at once
+flow events follow A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn --start 11559500
+
+#print each block events on a single line in JSON format
+flow events follow A.1654653399040a61.FlowToken.TokensDeposited --output json
+	`,
+	},
+	Flags: &followFlags,
+	Run:   follow,
+}
+
+func follow(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	events, errs, err := flow.SubscribeEvents(
+		ctx,
+		args,
+		followFlags.Start,
+		&flowkit.EventWorker{
+			Count:           followFlags.Workers,
+			BlocksPerWorker: followFlags.Batch,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Following events %s, press Ctrl+C to stop.", strings.Join(args, ", ")))
+
+	for blockEvents := range events {
+		command.PrintResult(&EventResult{BlockEvents: []flowsdk.BlockEvents{blockEvents}}, globalFlags.Format)
+	}
+
+	// errors are only sent if the subscription failed, otherwise the channel is closed when interrupted
+	return nil, <-errs
+}
@@ -0,0 +1,107 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+var CheckUpdatesCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "check-updates",
+		Short:   "Check that updating the deployed contracts follows the contract update rules",
+		Example: "flow project check-updates --network testnet",
+	},
+	Flags: &struct{}{},
+	RunS:  checkUpdates,
+}
+
+func checkUpdates(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	plans, err := flow.PlanDeployment(context.Background(), flowkit.UpdateExistingContract(true))
+	if err != nil {
+		return nil, err
+	}
+
+	// only contracts which exist with different code are updated, and updating is enabled so the
+	// contracts are only blocked by breaking the update rules
+	updates := make([]flowkit.ContractPlan, 0)
+	invalid := 0
+	for _, plan := range plans {
+		switch plan.Action {
+		case flowkit.ContractBlocked:
+			invalid++
+			logger.Info(fmt.Sprintf("%s Contract %s can't be updated:", output.ErrorEmoji(), plan.Contract.Name))
+			for _, issue := range plan.UpdateIssues.Errors() {
+				logger.Info(fmt.Sprintf("  - %s", issue))
+			}
+			fallthrough
+		case flowkit.ContractUpdate:
+			updates = append(updates, plan)
+		}
+	}
+
+	if invalid > 0 {
+		return nil, fmt.Errorf("%d of %d contract updates break the contract update rules", invalid, len(updates))
+	}
+
+	return &checkUpdatesResult{updates: updates}, nil
+}
+
+type checkUpdatesResult struct {
+	updates []flowkit.ContractPlan
+}
+
+func (r *checkUpdatesResult) JSON() any {
+	result := make(map[string]any)
+	for _, plan := range r.updates {
+		result[plan.Contract.Name] = updateIssuesJSON(plan.UpdateIssues)
+	}
+	return result
+}
+
+func (r *checkUpdatesResult) String() string {
+	if len(r.updates) == 0 {
+		return "No contracts need to be updated"
+	}
+
+	var b bytes.Buffer
+	for _, plan := range r.updates {
+		_, _ = fmt.Fprintf(&b, "%s Contract %s can be updated\n", output.OkEmoji(), plan.Contract.Name)
+		writeUpdateIssues(&b, plan.UpdateIssues)
+	}
+	return b.String()
+}
+
+func (r *checkUpdatesResult) Oneliner() string {
+	return fmt.Sprintf("Updates checked: %d", len(r.updates))
+}
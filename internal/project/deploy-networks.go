@@ -0,0 +1,258 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+const (
+	stopOnFailure     = "stop"
+	continueOnFailure = "continue"
+)
+
+// deployStatus is set when deploying to one of several networks failed,
+// since the results of the other networks are still reported the command doesn't return an error.
+var deployStatus = 0
+
+// deployNetworks deploys the project to each network listed in the network flag, or with the all-networks flag to every
+// configured network that has deployments. Deploying to a single network listed in the network flag returns the same
+// result as before, otherwise the networks are deployed in turn and the result of each network is reported.
+func deployNetworks(
+	args []string,
+	global command.GlobalFlags,
+	logger output.Logger,
+	state *flowkit.State,
+	services command.NetworkServices,
+) (command.Result, error) {
+	if deployFlags.OnFailure != stopOnFailure && deployFlags.OnFailure != continueOnFailure {
+		return nil, fmt.Errorf("invalid on-failure value %s, use %s or %s", deployFlags.OnFailure, stopOnFailure, continueOnFailure)
+	}
+
+	networks, err := deployNetworkNames(global.Network, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(networks) == 1 && !deployFlags.AllNetworks {
+		flow, err := services(networks[0])
+		if err != nil {
+			return nil, err
+		}
+		return deploy(args, global, logger, flow, state)
+	}
+
+	if global.Host != "" {
+		return nil, fmt.Errorf("the host flag can't be combined with deploying to several networks")
+	}
+
+	result := &networksDeployResult{}
+	failed := false
+	for _, network := range networks {
+		if failed && deployFlags.OnFailure == stopOnFailure {
+			result.deployments = append(result.deployments, networkDeployment{network: network, skipped: true})
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("\nDeploying to network %s", output.Bold(network)))
+
+		flow, err := services(network)
+		var networkResult command.Result
+		if err == nil {
+			networkResult, err = deploy(args, global, logger, flow, state)
+		}
+		if err != nil {
+			failed = true
+			logger.Error(fmt.Sprintf("Failed to deploy to network %s: %s", network, err))
+		}
+
+		result.deployments = append(result.deployments, networkDeployment{
+			network: network,
+			result:  networkResult,
+			err:     err,
+		})
+	}
+
+	if failed {
+		deployStatus = 1
+	}
+
+	return result, nil
+}
+
+// deployNetworkNames returns the networks to deploy to, in the order listed in the network flag
+// or in the order of the configuration for the all-networks flag.
+func deployNetworkNames(networkFlag string, state *flowkit.State) ([]string, error) {
+	networks := make([]string, 0)
+
+	if deployFlags.AllNetworks {
+		for _, network := range *state.Networks() {
+			if len(state.Deployments().ByNetwork(network.Name)) > 0 {
+				networks = append(networks, network.Name)
+			}
+		}
+		if len(networks) == 0 {
+			return nil, fmt.Errorf("no networks with deployments found in the configuration")
+		}
+		return networks, nil
+	}
+
+	listed := make(map[string]bool)
+	for _, network := range strings.Split(networkFlag, ",") {
+		network = strings.TrimSpace(network)
+		if network == "" {
+			continue
+		}
+		if listed[network] {
+			return nil, fmt.Errorf("network %s is listed more than once", network)
+		}
+		listed[network] = true
+		networks = append(networks, network)
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("no network provided")
+	}
+
+	return networks, nil
+}
+
+type networkDeployment struct {
+	network string
+	result  command.Result
+	err     error
+	skipped bool
+}
+
+func (d networkDeployment) status() string {
+	if d.skipped {
+		return "skipped"
+	}
+	if d.err != nil {
+		return "failed"
+	}
+	return "success"
+}
+
+type networksDeployResult struct {
+	deployments []networkDeployment
+}
+
+func (r *networksDeployResult) count(status string) int {
+	count := 0
+	for _, deployment := range r.deployments {
+		if deployment.status() == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *networksDeployResult) JSON() any {
+	result := make(map[string]any)
+
+	for _, deployment := range r.deployments {
+		network := map[string]any{
+			"status": deployment.status(),
+		}
+		if deployment.result != nil {
+			network["result"] = deployment.result.JSON()
+		}
+		if deployment.err != nil {
+			network["error"] = deployment.err.Error()
+		}
+
+		var projectErr *flowkit.ProjectDeploymentError
+		if errors.As(deployment.err, &projectErr) {
+			network["contracts"] = errorsJSON(projectErr.Contracts())
+			network["hooks"] = errorsJSON(projectErr.Hooks())
+			network["rollbacks"] = rollbacksJSON(projectErr.Rollbacks())
+		}
+
+		result[deployment.network] = network
+	}
+
+	return result
+}
+
+func errorsJSON(errs map[string]error) map[string]string {
+	result := make(map[string]string, len(errs))
+	for name, err := range errs {
+		result[name] = err.Error()
+	}
+	return result
+}
+
+func rollbacksJSON(rollbacks []flowkit.ContractRollback) []map[string]any {
+	result := make([]map[string]any, 0, len(rollbacks))
+	for _, rollback := range rollbacks {
+		r := map[string]any{
+			"contract": rollback.Contract.Name,
+			"address":  rollback.Contract.AccountAddress.String(),
+			"removed":  rollback.Removed,
+		}
+		if rollback.Error != nil {
+			r["error"] = rollback.Error.Error()
+		} else {
+			r["id"] = rollback.ID.String()
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func (r *networksDeployResult) String() string {
+	var b bytes.Buffer
+
+	for _, deployment := range r.deployments {
+		switch deployment.status() {
+		case "skipped":
+			_, _ = fmt.Fprintf(&b, "%s Network %s: skipped after a previous network failed\n", output.WarningEmoji(), deployment.network)
+		case "failed":
+			_, _ = fmt.Fprintf(&b, "%s Network %s: %s\n", output.ErrorEmoji(), deployment.network, deployment.err)
+		default:
+			_, _ = fmt.Fprintf(&b, "%s Network %s: success\n", output.OkEmoji(), deployment.network)
+		}
+
+		if deployment.result != nil {
+			if out := deployment.result.String(); out != "" {
+				_, _ = fmt.Fprintf(&b, "\n%s\n", out)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n%s\n", r.Oneliner())
+	return b.String()
+}
+
+func (r *networksDeployResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Networks: %d, Succeeded: %d, Failed: %d, Skipped: %d",
+		len(r.deployments),
+		r.count("success"),
+		r.count("failed"),
+		r.count("skipped"),
+	)
+}
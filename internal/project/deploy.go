@@ -36,8 +36,16 @@ import (
 )
 
 type flagsDeploy struct {
-	Update   bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
-	ShowDiff bool `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Update      bool   `flag:"update" default:"false" info:"use update flag to update existing contracts"`
+	ShowDiff    bool   `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Parallel    bool   `flag:"parallel" default:"false" info:"use parallel flag to deploy contracts that don't import each other at the same time"`
+	Concurrency int    `flag:"concurrency" default:"4" info:"maximum number of contracts deployed at the same time with the parallel flag"`
+	Plan        bool   `flag:"plan" default:"false" info:"use plan flag to show the changes deploying would make without sending any transactions"`
+	IgnoreLock  bool   `flag:"ignore-lock" default:"false" info:"use ignore-lock flag to check every contract on the network instead of skipping contracts unchanged since the last deploy recorded in flow.lock"`
+	SkipCheck   bool   `flag:"skip-update-check" default:"false" info:"use skip-update-check flag to send contract updates without checking them against the contract update rules"`
+	Atomic      bool   `flag:"atomic" default:"false" info:"use atomic flag to roll back the contracts deployed before a failure, restoring updated contracts and removing added contracts on the emulator"`
+	AllNetworks bool   `flag:"all-networks" default:"false" info:"use all-networks flag to deploy to every network with deployments in the configuration"`
+	OnFailure   string `flag:"on-failure" default:"stop" info:"when deploying to several networks, stop or continue with the remaining networks after a network fails"`
 }
 
 var deployFlags = flagsDeploy{}
@@ -46,10 +54,11 @@ var DeployCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "deploy",
 		Short:   "Deploy Cadence contracts",
-		Example: "flow project deploy --network testnet",
+		Example: "flow project deploy --network testnet\nflow project deploy --network testnet --update --plan\nflow project deploy --network emulator,testnet --on-failure continue",
 	},
-	Flags: &deployFlags,
-	RunS:  deploy,
+	Flags:  &deployFlags,
+	RunN:   deployNetworks,
+	Status: &deployStatus,
 }
 
 func deploy(
@@ -60,19 +69,51 @@ func deploy(
 	state *flowkit.State,
 ) (command.Result, error) {
 
-	if flow.Network() == config.MainnetNetwork { // if using mainnet check for standard contract usage
+	if flow.Network().Name == config.MainnetNetwork.Name { // if using mainnet check for standard contract usage
 		err := checkForStandardContractUsageOnMainnet(state, logger, global.Yes)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if deployFlags.Plan {
+		plans, err := flow.PlanDeployment(context.Background(), flowkit.UpdateExistingContract(deployFlags.Update))
+		if err != nil {
+			return nil, err
+		}
+		return &planResult{network: flow.Network().Name, plans: plans}, nil
+	}
+
 	deployFunc := flowkit.UpdateExistingContract(deployFlags.Update)
 	if deployFlags.ShowDiff {
 		deployFunc = util.ShowContractDiffPrompt(logger)
 	}
 
-	c, err := flow.DeployProject(context.Background(), deployFunc)
+	var options []flowkit.DeployOption
+	if deployFlags.IgnoreLock {
+		options = append(options, flowkit.WithIgnoreLockfile())
+	}
+	if deployFlags.SkipCheck {
+		options = append(options, flowkit.WithoutUpdateCheck())
+	}
+	if deployFlags.Atomic {
+		if flow.Network().Name != config.EmulatorNetwork.Name {
+			logger.Info(fmt.Sprintf(
+				"%s Contracts can't be removed on network %s, so if the deploy fails only updated contracts are rolled back and added contracts remain deployed",
+				output.WarningEmoji(),
+				flow.Network().Name,
+			))
+		}
+		options = append(options, flowkit.WithAtomicDeploy())
+	}
+	if deployFlags.Parallel {
+		if deployFlags.ShowDiff {
+			return nil, fmt.Errorf("the show-diff flag can't be combined with the parallel flag")
+		}
+		options = append(options, flowkit.WithDeployConcurrency(deployFlags.Concurrency))
+	}
+
+	c, err := flow.DeployProject(context.Background(), deployFunc, options...)
 	if err != nil {
 		var projectErr *flowkit.ProjectDeploymentError
 		if errors.As(err, &projectErr) {
@@ -84,7 +125,22 @@ func deploy(
 					err.Error(),
 				))
 			}
-			return nil, fmt.Errorf("failed deploying all contracts")
+			for hook, err := range projectErr.Hooks() {
+				logger.Info(fmt.Sprintf(
+					"%s Failed to run deployment hook %s: %s",
+					output.ErrorEmoji(),
+					hook,
+					err.Error(),
+				))
+			}
+			if rollbacks := projectErr.Rollbacks(); len(rollbacks) > 0 {
+				rolledBack := logRollbacks(logger, rollbacks)
+				return nil, &deployFailedError{
+					message: fmt.Sprintf("failed deploying all contracts, rolled back %d of %d deployed contracts", rolledBack, len(rollbacks)),
+					err:     projectErr,
+				}
+			}
+			return nil, &deployFailedError{message: "failed deploying all contracts", err: projectErr}
 		}
 		return nil, err
 	}
@@ -92,6 +148,53 @@ func deploy(
 	return &deployResult{c}, nil
 }
 
+// deployFailedError summarises a failed project deployment, the contract, hook and rollback errors
+// are logged and remain available by unwrapping the error.
+type deployFailedError struct {
+	message string
+	err     *flowkit.ProjectDeploymentError
+}
+
+func (e *deployFailedError) Error() string {
+	return e.message
+}
+
+func (e *deployFailedError) Unwrap() error {
+	return e.err
+}
+
+// logRollbacks logs how each contract was rolled back and returns the number of contracts rolled back successfully.
+func logRollbacks(logger output.Logger, rollbacks []flowkit.ContractRollback) int {
+	rolledBack := 0
+	for _, rollback := range rollbacks {
+		if rollback.Error != nil {
+			logger.Info(fmt.Sprintf(
+				"%s Failed to roll back contract %s, it remains deployed on 0x%s: %s",
+				output.ErrorEmoji(),
+				rollback.Contract.Name,
+				rollback.Contract.AccountAddress,
+				rollback.Error.Error(),
+			))
+			continue
+		}
+
+		rolledBack++
+		action := "Restored previous code of"
+		if rollback.Removed {
+			action = "Removed"
+		}
+		logger.Info(fmt.Sprintf(
+			"%s %s contract %s on 0x%s (%s)",
+			output.WarningEmoji(),
+			action,
+			rollback.Contract.Name,
+			rollback.Contract.AccountAddress,
+			rollback.ID.String(),
+		))
+	}
+	return rolledBack
+}
+
 type deployResult struct {
 	contracts []*project.Contract
 }
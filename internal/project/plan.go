@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type planResult struct {
+	network string
+	plans   []flowkit.ContractPlan
+}
+
+func (r *planResult) count(action flowkit.ContractPlanAction) int {
+	count := 0
+	for _, plan := range r.plans {
+		if plan.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *planResult) JSON() any {
+	result := make([]any, 0, len(r.plans))
+
+	for _, plan := range r.plans {
+		imports := make(map[string]string)
+		for name, address := range plan.Imports {
+			imports[name] = "0x" + address.Hex()
+		}
+
+		contract := map[string]any{
+			"name":    plan.Contract.Name,
+			"account": plan.Contract.AccountName,
+			"address": "0x" + plan.Contract.AccountAddress.Hex(),
+			"action":  string(plan.Action),
+			"imports": imports,
+		}
+		if plan.Action == flowkit.ContractUpdate || plan.Action == flowkit.ContractBlocked {
+			contract["diff"] = lineDiff(plan.ExistingCode, plan.Code)
+		}
+		if plan.Reason != "" {
+			contract["reason"] = plan.Reason
+		}
+		if len(plan.UpdateIssues) > 0 {
+			contract["update_issues"] = updateIssuesJSON(plan.UpdateIssues)
+		}
+
+		result = append(result, contract)
+	}
+
+	return result
+}
+
+func (r *planResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Deployment plan for network %s\n\n", r.network)
+	_, _ = fmt.Fprintf(writer, "Contract\tAccount\tAddress\tAction\n")
+	for _, plan := range r.plans {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			plan.Contract.Name,
+			plan.Contract.AccountName,
+			"0x"+plan.Contract.AccountAddress.Hex(),
+			plan.Action,
+		)
+	}
+	_ = writer.Flush()
+
+	for _, plan := range r.plans {
+		if plan.Action == flowkit.ContractUnchanged {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&b, "\n%s (%s)\n", plan.Contract.Name, plan.Action)
+		if plan.Reason != "" {
+			_, _ = fmt.Fprintf(&b, "Reason: %s\n", plan.Reason)
+		}
+		writeUpdateIssues(&b, plan.UpdateIssues)
+
+		names := make([]string, 0, len(plan.Imports))
+		for name := range plan.Imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			_, _ = fmt.Fprintf(&b, "Import %s: %s\n", name, "0x"+plan.Imports[name].Hex())
+		}
+
+		if plan.Action != flowkit.ContractAdd {
+			_, _ = fmt.Fprintf(&b, "\n%s", lineDiff(plan.ExistingCode, plan.Code))
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n%s\n", r.Oneliner())
+	return b.String()
+}
+
+func (r *planResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Add: %d, Update: %d, Unchanged: %d, Blocked: %d",
+		r.count(flowkit.ContractAdd),
+		r.count(flowkit.ContractUpdate),
+		r.count(flowkit.ContractUnchanged),
+		r.count(flowkit.ContractBlocked),
+	)
+}
+
+func updateIssuesJSON(issues project.UpdateIssues) []any {
+	result := make([]any, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, map[string]any{
+			"message": issue.Message,
+			"line":    issue.Line,
+			"warning": issue.Warning,
+		})
+	}
+	return result
+}
+
+func writeUpdateIssues(w io.Writer, issues project.UpdateIssues) {
+	for _, issue := range issues {
+		badge := output.ErrorEmoji()
+		if issue.Warning {
+			badge = output.WarningEmoji()
+		}
+		_, _ = fmt.Fprintf(w, "%s %s\n", badge, issue)
+	}
+}
+
+// lineDiff returns the line by line diff from the existing code to the new code,
+// prefixing removed lines with "-", added lines with "+" and unchanged lines with a space.
+func lineDiff(existing []byte, code []byte) string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(string(existing), string(code))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var out strings.Builder
+	for _, diff := range diffs {
+		prefix := " "
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+
+		for _, line := range strings.SplitAfter(diff.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			out.WriteString(prefix + line)
+			if !strings.HasSuffix(line, "\n") {
+				out.WriteString("\n")
+			}
+		}
+	}
+
+	return out.String()
+}
@@ -31,4 +31,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	DeployCommand.AddToParent(Cmd)
+	StatusCommand.AddToParent(Cmd)
+	CheckUpdatesCommand.AddToParent(Cmd)
 }
@@ -19,15 +19,18 @@
 package project
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -39,6 +42,75 @@ func Test_ProjectDeploy(t *testing.T) {
 		srv.DeployProject.Return(nil, &flowkit.ProjectDeploymentError{})
 		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "failed deploying all contracts")
+
+		var projectErr *flowkit.ProjectDeploymentError
+		assert.ErrorAs(t, err, &projectErr)
+	})
+
+	t.Run("Success parallel", func(t *testing.T) {
+		deployFlags.Parallel = true
+		srv.DeployProject.Run(func(args mock.Arguments) {
+			options := args.Get(2).([]flowkit.DeployOption)
+			assert.Len(t, options, 1)
+		}).Return([]*project.Contract{}, nil)
+
+		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		deployFlags.Parallel = false // reset
+	})
+
+	t.Run("Success atomic", func(t *testing.T) {
+		deployFlags.Atomic = true
+		deployFlags.Parallel = true
+		srv.DeployProject.Run(func(args mock.Arguments) {
+			options := args.Get(2).([]flowkit.DeployOption)
+			assert.Len(t, options, 2)
+		}).Return([]*project.Contract{}, nil)
+
+		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		deployFlags.Atomic = false // reset
+		deployFlags.Parallel = false
+	})
+
+	t.Run("Fail parallel with show diff", func(t *testing.T) {
+		deployFlags.Parallel = true
+		deployFlags.ShowDiff = true
+
+		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "the show-diff flag can't be combined with the parallel flag")
+		deployFlags.Parallel = false // reset
+		deployFlags.ShowDiff = false
+	})
+
+	t.Run("Success plan", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		deployFlags.Plan = true
+		srv.PlanDeployment.Return([]flowkit.ContractPlan{{
+			Contract:     &project.Contract{Name: "Hello", AccountName: "emulator-account", AccountAddress: flow.HexToAddress("0x01")},
+			Action:       flowkit.ContractUpdate,
+			Imports:      map[string]flow.Address{"Foo": flow.HexToAddress("0x02")},
+			Code:         []byte("import Foo from 0x02\naccess(all) contract Hello {}\n"),
+			ExistingCode: []byte("access(all) contract Hello {}\n"),
+		}, {
+			Contract: &project.Contract{Name: "Foo", AccountName: "emulator-account", AccountAddress: flow.HexToAddress("0x02")},
+			Action:   flowkit.ContractUnchanged,
+		}}, nil)
+
+		result, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		srv.Mock.AssertNotCalled(t, "DeployProject", mock.Anything, mock.Anything, mock.Anything)
+
+		assert.Equal(t, "Add: 0, Update: 1, Unchanged: 1, Blocked: 0", result.Oneliner())
+		assert.Contains(t, result.String(), "Import Foo: 0x0000000000000002")
+		assert.Contains(t, result.String(), "+import Foo from 0x02\n access(all) contract Hello {}\n")
+
+		plans := result.JSON().([]any)
+		require.Len(t, plans, 2)
+		assert.Equal(t, "update", plans[0].(map[string]any)["action"])
+		assert.Equal(t, map[string]string{"Foo": "0x0000000000000002"}, plans[0].(map[string]any)["imports"])
+		assert.NotContains(t, plans[1].(map[string]any), "diff")
+		deployFlags.Plan = false // reset
 	})
 
 	t.Run("Success replace standard contracts", func(t *testing.T) {
@@ -76,3 +148,195 @@ func Test_ProjectDeploy(t *testing.T) {
 	})
 
 }
+
+func Test_ProjectDeployNetworks(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.DeployProject.Return([]*project.Contract{{Name: "Hello", AccountAddress: flow.HexToAddress("0x01")}}, nil)
+
+	var requested []string
+	services := func(network string) (flowkit.Services, error) {
+		requested = append(requested, network)
+		if network == config.TestnetNetwork.Name {
+			return nil, fmt.Errorf("network unavailable")
+		}
+		return srv.Mock, nil
+	}
+
+	t.Run("Success single network", func(t *testing.T) {
+		requested = nil
+		deployFlags.OnFailure = stopOnFailure
+
+		result, err := deployNetworks([]string{}, command.GlobalFlags{Network: "emulator"}, util.NoLogger, state, services)
+		require.NoError(t, err)
+		assert.IsType(t, &deployResult{}, result)
+		assert.Equal(t, []string{"emulator"}, requested)
+	})
+
+	t.Run("Fail stop on failure", func(t *testing.T) {
+		requested = nil
+		deployFlags.OnFailure = stopOnFailure
+
+		global := command.GlobalFlags{Network: "emulator, testnet,mainnet"}
+		result, err := deployNetworks([]string{}, global, util.NoLogger, state, services)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"emulator", "testnet"}, requested)
+		assert.Equal(t, 1, deployStatus)
+		assert.Equal(t, "Networks: 3, Succeeded: 1, Failed: 1, Skipped: 1", result.Oneliner())
+
+		networks := result.JSON().(map[string]any)
+		assert.Equal(t, "success", networks["emulator"].(map[string]any)["status"])
+		assert.Equal(t, map[string]any{"Hello": "0000000000000001"}, networks["emulator"].(map[string]any)["result"])
+		assert.Equal(t, "network unavailable", networks["testnet"].(map[string]any)["error"])
+		assert.Equal(t, "skipped", networks["mainnet"].(map[string]any)["status"])
+		deployStatus = 0 // reset
+	})
+
+	t.Run("Fail continue on failure", func(t *testing.T) {
+		requested = nil
+		deployFlags.OnFailure = continueOnFailure
+
+		global := command.GlobalFlags{Network: "testnet,emulator"}
+		result, err := deployNetworks([]string{}, global, util.NoLogger, state, services)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"testnet", "emulator"}, requested)
+		assert.Equal(t, 1, deployStatus)
+		assert.Equal(t, "Networks: 2, Succeeded: 1, Failed: 1, Skipped: 0", result.Oneliner())
+		deployStatus = 0 // reset
+		deployFlags.OnFailure = stopOnFailure
+	})
+
+	t.Run("Fail contract errors", func(t *testing.T) {
+		requested = nil
+		deployFlags.OnFailure = continueOnFailure
+		srv.DeployProject.Return(nil, &flowkit.ProjectDeploymentError{})
+
+		global := command.GlobalFlags{Network: "emulator,testnet"}
+		result, err := deployNetworks([]string{}, global, util.NoLogger, state, services)
+		require.NoError(t, err)
+
+		emulator := result.JSON().(map[string]any)["emulator"].(map[string]any)
+		assert.Equal(t, "failed", emulator["status"])
+		assert.Equal(t, "failed deploying all contracts", emulator["error"])
+		assert.Equal(t, map[string]string{}, emulator["contracts"])
+		assert.Equal(t, map[string]string{}, emulator["hooks"])
+		assert.Equal(t, []map[string]any{}, emulator["rollbacks"])
+
+		testnet := result.JSON().(map[string]any)["testnet"].(map[string]any)
+		assert.NotContains(t, testnet, "contracts")
+
+		srv.DeployProject.Return([]*project.Contract{{Name: "Hello", AccountAddress: flow.HexToAddress("0x01")}}, nil)
+		deployStatus = 0 // reset
+		deployFlags.OnFailure = stopOnFailure
+	})
+
+	t.Run("Success all networks", func(t *testing.T) {
+		requested = nil
+		deployFlags.AllNetworks = true
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: config.EmulatorNetwork.Name,
+			Account: config.DefaultEmulator.ServiceAccount,
+		})
+
+		result, err := deployNetworks([]string{}, command.GlobalFlags{Network: "emulator"}, util.NoLogger, state, services)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"emulator"}, requested)
+		assert.Equal(t, "Networks: 1, Succeeded: 1, Failed: 0, Skipped: 0", result.Oneliner())
+		deployFlags.AllNetworks = false // reset
+	})
+
+	t.Run("Fail invalid arguments", func(t *testing.T) {
+		deployFlags.OnFailure = "retry"
+		_, err := deployNetworks([]string{}, command.GlobalFlags{Network: "emulator"}, util.NoLogger, state, services)
+		assert.EqualError(t, err, "invalid on-failure value retry, use stop or continue")
+		deployFlags.OnFailure = stopOnFailure
+
+		_, err = deployNetworks([]string{}, command.GlobalFlags{Network: "emulator,emulator"}, util.NoLogger, state, services)
+		assert.EqualError(t, err, "network emulator is listed more than once")
+
+		global := command.GlobalFlags{Network: "emulator,testnet", Host: "127.0.0.1:3569"}
+		_, err = deployNetworks([]string{}, global, util.NoLogger, state, services)
+		assert.EqualError(t, err, "the host flag can't be combined with deploying to several networks")
+	})
+}
+
+func Test_ProjectStatus(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		srv.DeploymentStatus.Return([]flowkit.ContractStatus{{
+			Name:       "Hello",
+			Account:    "emulator-account",
+			Address:    flow.HexToAddress("0x01"),
+			Locked:     &flowkit.LockedContract{TransactionID: "abc", BlockHeight: 10},
+			Configured: true,
+			OnChain:    true,
+		}, {
+			Name:         "Foo",
+			Account:      "emulator-account",
+			Address:      flow.HexToAddress("0x01"),
+			Locked:       &flowkit.LockedContract{},
+			Configured:   true,
+			LocalChanged: true,
+		}, {
+			Name:    "Bar",
+			Account: "emulator-account",
+			Address: flow.HexToAddress("0x01"),
+		}}, nil)
+
+		result, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Contracts: 3, Drifted: 2", result.Oneliner())
+		assert.Contains(t, result.String(), "up to date")
+		assert.Contains(t, result.String(), "changed locally, missing on-chain")
+		assert.Contains(t, result.String(), "not deployed")
+
+		statuses := result.JSON().([]any)
+		require.Len(t, statuses, 3)
+		assert.Empty(t, statuses[0].(map[string]any)["drift"])
+		assert.Equal(t, uint64(10), statuses[0].(map[string]any)["block_height"])
+		assert.Equal(t, []string{"changed locally", "missing on-chain"}, statuses[1].(map[string]any)["drift"])
+	})
+}
+
+func Test_ProjectCheckUpdates(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	hello := &project.Contract{Name: "Hello", AccountName: "emulator-account", AccountAddress: flow.HexToAddress("0x01")}
+	foo := &project.Contract{Name: "Foo", AccountName: "emulator-account", AccountAddress: flow.HexToAddress("0x01")}
+
+	t.Run("Success", func(t *testing.T) {
+		srv.PlanDeployment.Return([]flowkit.ContractPlan{{
+			Contract: hello,
+			Action:   flowkit.ContractUpdate,
+			UpdateIssues: project.UpdateIssues{{
+				Message: "field `x` was removed from `Hello`, the data stored in it can't be accessed anymore",
+				Warning: true,
+			}},
+		}, {
+			Contract: foo,
+			Action:   flowkit.ContractUnchanged,
+		}}, nil)
+
+		result, err := checkUpdates([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "Updates checked: 1", result.Oneliner())
+		assert.Contains(t, result.String(), "Contract Hello can be updated")
+		assert.Contains(t, result.String(), "field `x` was removed")
+		assert.Len(t, result.JSON().(map[string]any)["Hello"], 1)
+	})
+
+	t.Run("Fail invalid update", func(t *testing.T) {
+		srv.PlanDeployment.Return([]flowkit.ContractPlan{{
+			Contract:     hello,
+			Action:       flowkit.ContractBlocked,
+			UpdateIssues: project.UpdateIssues{{Message: "found new field `y` in `Hello`", Line: 3}},
+		}, {
+			Contract: foo,
+			Action:   flowkit.ContractUpdate,
+		}}, nil)
+
+		_, err := checkUpdates([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "1 of 2 contract updates break the contract update rules")
+	})
+}
@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var StatusCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "status",
+		Short:   "Compare deployed contracts with the lockfile and local sources",
+		Example: "flow project status --network testnet",
+	},
+	Flags: &struct{}{},
+	RunS:  status,
+}
+
+func status(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	statuses, err := flow.DeploymentStatus(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &statusResult{network: flow.Network().Name, statuses: statuses}, nil
+}
+
+type statusResult struct {
+	network  string
+	statuses []flowkit.ContractStatus
+}
+
+// drift describes how the contract differs between the lockfile, local sources and the network.
+func drift(status flowkit.ContractStatus) []string {
+	if status.Locked == nil {
+		if status.OnChain {
+			return []string{"not in lockfile"}
+		}
+		return []string{"not deployed"}
+	}
+
+	var drift []string
+	if !status.Configured {
+		drift = append(drift, "removed from configuration")
+	}
+	if status.LocalChanged {
+		drift = append(drift, "changed locally")
+	}
+	if !status.OnChain {
+		drift = append(drift, "missing on-chain")
+	}
+	if status.OnChainChanged {
+		drift = append(drift, "changed on-chain")
+	}
+	return drift
+}
+
+func (r *statusResult) drifted() int {
+	count := 0
+	for _, status := range r.statuses {
+		if status.Drifted() {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *statusResult) JSON() any {
+	result := make([]any, 0, len(r.statuses))
+
+	for _, status := range r.statuses {
+		contract := map[string]any{
+			"name":    status.Name,
+			"account": status.Account,
+			"address": "0x" + status.Address.Hex(),
+			"drift":   drift(status),
+		}
+		if status.Locked != nil {
+			contract["transaction_id"] = status.Locked.TransactionID
+			contract["block_height"] = status.Locked.BlockHeight
+		}
+
+		result = append(result, contract)
+	}
+
+	return result
+}
+
+func (r *statusResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Deployment status for network %s\n\n", r.network)
+	_, _ = fmt.Fprintf(writer, "\tContract\tAccount\tAddress\tStatus\n")
+	for _, status := range r.statuses {
+		badge := output.OkEmoji()
+		description := "up to date"
+		if status.Drifted() {
+			badge = output.WarningEmoji()
+			description = strings.Join(drift(status), ", ")
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			badge,
+			status.Name,
+			status.Account,
+			"0x"+status.Address.Hex(),
+			description,
+		)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", r.Oneliner())
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *statusResult) Oneliner() string {
+	return fmt.Sprintf("Contracts: %d, Drifted: %d", len(r.statuses), r.drifted())
+}
@@ -32,6 +32,7 @@ var DeployCommand = &command.Command{
 		Example: "flow deploy",
 		GroupID: "project",
 	},
-	Flags: project.DeployCommand.Flags,
-	RunS:  project.DeployCommand.RunS,
+	Flags:  project.DeployCommand.Flags,
+	RunN:   project.DeployCommand.RunN,
+	Status: project.DeployCommand.Status,
 }
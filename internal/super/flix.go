@@ -46,7 +46,8 @@ type flixFlags struct {
 	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
 	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
 	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	GasLimit    string   `default:"1000" flag:"gas-limit" info:"transaction gas limit, or auto to set it from the estimated computation"`
+	GasMargin   uint64   `default:"20" flag:"gas-margin" info:"Percentage added to the estimated computation when the gas limit is set automatically"`
 }
 
 var flags = flixFlags{}
@@ -158,6 +159,7 @@ func execute(
 		Include:     flags.Include,
 		Exclude:     flags.Exclude,
 		GasLimit:    flags.GasLimit,
+		GasMargin:   flags.GasMargin,
 	}
 	return transactions.SendTransaction([]byte(cadenceWithImportsReplaced), args[1:], "", flow, state, transactionFlags)
 }
@@ -31,9 +31,10 @@ import (
 )
 
 type flagsGet struct {
-	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
+	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for the transaction to reach the --wait-for status, otherwise return the current result"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output. Valid values: events."`
+	WaitFor string   `default:"sealed" flag:"wait-for" info:"Transaction status to wait for. Valid values: finalized, executed, sealed."`
 }
 
 var getFlags = flagsGet{}
@@ -59,6 +60,11 @@ func get(
 ) (command.Result, error) {
 	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
 
+	err := setWaitStrategy(flow, getFlags.WaitFor)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, result, err := flow.GetTransactionByID(context.Background(), id, getFlags.Sealed)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,280 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSendBatch struct {
+	Concurrency int    `default:"1" flag:"concurrency" info:"Number of transactions sent at the same time, by default transactions are sent sequentially"`
+	GasLimit    uint64 `default:"1000" flag:"gas-limit" info:"Transaction gas limit used for transactions that don't set one in the manifest"`
+	WaitFor     string `default:"sealed" flag:"wait-for" info:"Transaction status to wait for. Valid values: finalized, executed, sealed."`
+}
+
+var sendBatchFlags = flagsSendBatch{}
+
+var sendBatchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "send-batch <manifest filename>",
+		Short:   "Send transactions listed in a manifest file",
+		Args:    cobra.ExactArgs(1),
+		Example: "flow transactions send-batch setup.yaml --concurrency 4",
+	},
+	Flags: &sendBatchFlags,
+	RunS:  sendBatch,
+}
+
+// batchManifest lists the transactions sent by the send-batch command.
+//
+// The manifest can be written in YAML or JSON, transaction files are resolved relative to the manifest.
+type batchManifest struct {
+	Transactions []batchManifestTransaction `yaml:"transactions"`
+}
+
+type batchManifestTransaction struct {
+	Name        string   `yaml:"name"`
+	File        string   `yaml:"file"`
+	Args        []string `yaml:"args"`
+	ArgsJSON    string   `yaml:"argsJSON"`
+	Signer      string   `yaml:"signer"`
+	Proposer    string   `yaml:"proposer"`
+	Payer       string   `yaml:"payer"`
+	Authorizers []string `yaml:"authorizers"`
+	GasLimit    uint64   `yaml:"gasLimit"`
+}
+
+func sendBatch(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	manifestPath := args[0]
+
+	manifest, err := loadBatchManifest(state, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]flowkit.BatchTransaction, len(manifest.Transactions))
+	for i, tx := range manifest.Transactions {
+		txs[i], err = batchTransaction(state, filepath.Dir(manifestPath), tx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction %s: %w", tx.displayName(i), err)
+		}
+	}
+
+	err = setWaitStrategy(flow, sendBatchFlags.WaitFor)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := flow.SendTransactions(context.Background(), txs, sendBatchFlags.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchResult{
+		transactions: manifest.Transactions,
+		results:      results,
+	}, nil
+}
+
+func loadBatchManifest(state *flowkit.State, path string) (*batchManifest, error) {
+	data, err := state.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading manifest file: %w", err)
+	}
+
+	// JSON is valid YAML, so both formats are decoded the same way
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifest batchManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest file: %w", err)
+	}
+
+	if len(manifest.Transactions) == 0 {
+		return nil, fmt.Errorf("manifest file %s doesn't list any transactions", path)
+	}
+
+	return &manifest, nil
+}
+
+// batchTransaction reads the transaction file and resolves the arguments and accounts of the manifest transaction.
+func batchTransaction(state *flowkit.State, dir string, tx batchManifestTransaction) (flowkit.BatchTransaction, error) {
+	if tx.File == "" {
+		return flowkit.BatchTransaction{}, fmt.Errorf("missing transaction file")
+	}
+
+	location := tx.File
+	if !filepath.IsAbs(location) {
+		location = filepath.Join(dir, location)
+	}
+
+	code, err := state.ReadFile(location)
+	if err != nil {
+		return flowkit.BatchTransaction{}, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var transactionArgs []cadence.Value
+	if tx.ArgsJSON != "" {
+		if len(tx.Args) > 0 {
+			return flowkit.BatchTransaction{}, fmt.Errorf("args and argsJSON cannot be combined")
+		}
+		transactionArgs, err = arguments.ParseJSON(tx.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(tx.Args, code, location)
+	}
+	if err != nil {
+		return flowkit.BatchTransaction{}, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	roles, err := resolveRoles(state, tx.Signer, tx.Proposer, tx.Payer, tx.Authorizers)
+	if err != nil {
+		return flowkit.BatchTransaction{}, err
+	}
+
+	gasLimit := tx.GasLimit
+	if gasLimit == 0 {
+		gasLimit = sendBatchFlags.GasLimit
+	}
+
+	return flowkit.BatchTransaction{
+		Accounts: roles,
+		Script:   flowkit.Script{Code: code, Args: transactionArgs, Location: location},
+		GasLimit: gasLimit,
+	}, nil
+}
+
+// displayName is the transaction name if set, otherwise its position in the manifest.
+func (t batchManifestTransaction) displayName(index int) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return fmt.Sprintf("#%d (%s)", index+1, t.File)
+}
+
+type batchResult struct {
+	transactions []batchManifestTransaction
+	results      []flowkit.BatchResult
+}
+
+// failed returns the error of the transaction if it couldn't be sent or its execution failed.
+func failed(result flowkit.BatchResult) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Result != nil && result.Result.Error != nil {
+		return result.Result.Error
+	}
+	return nil
+}
+
+func (r *batchResult) failures() int {
+	count := 0
+	for _, result := range r.results {
+		if failed(result) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *batchResult) JSON() any {
+	results := make([]any, 0, len(r.results))
+
+	for i, res := range r.results {
+		result := make(map[string]any)
+		result["name"] = r.transactions[i].Name
+		result["file"] = r.transactions[i].File
+
+		if res.Tx != nil {
+			result["id"] = res.Tx.ID().String()
+		}
+		if res.Result != nil {
+			result["block_id"] = res.Result.BlockID.String()
+			result["block_height"] = res.Result.BlockHeight
+			result["status"] = res.Result.Status.String()
+		}
+		if err := failed(res); err != nil {
+			result["error"] = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (r *batchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "\tTransaction\tID\tStatus\tError\n")
+	for i, res := range r.results {
+		id := "-"
+		if res.Tx != nil {
+			id = res.Tx.ID().String()
+		}
+
+		status := "-"
+		if res.Result != nil {
+			status = res.Result.Status.String()
+		}
+
+		badge := output.OkEmoji()
+		errMsg := "-"
+		if err := failed(res); err != nil {
+			badge = output.ErrorEmoji()
+			// only the first line fits in the table, the full error is included in the JSON output
+			errMsg = strings.SplitN(err.Error(), "\n", 2)[0]
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			badge, r.transactions[i].displayName(i), id, status, errMsg,
+		)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nSent %d transactions, %d failed\n", len(r.results), r.failures())
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *batchResult) Oneliner() string {
+	return fmt.Sprintf("Sent: %d, Failed: %d", len(r.results), r.failures())
+}
@@ -35,6 +35,7 @@ import (
 type flagsSendSigned struct {
 	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	WaitFor string   `default:"sealed" flag:"wait-for" info:"Transaction status to wait for. Valid values: finalized, executed, sealed."`
 }
 
 var sendSignedFlags = flagsSendSigned{}
@@ -57,6 +58,11 @@ func sendSigned(
 	reader flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
+	err := setWaitStrategy(flow, sendSignedFlags.WaitFor)
+	if err != nil {
+		return nil, err
+	}
+
 	filename := args[0]
 
 	code, err := reader.ReadFile(filename)
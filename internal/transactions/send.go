@@ -21,8 +21,10 @@ package transactions
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
@@ -41,7 +43,10 @@ type Flags struct {
 	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
 	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
 	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	GasLimit    string   `default:"1000" flag:"gas-limit" info:"transaction gas limit, or auto to set it from the estimated computation"`
+	GasMargin   uint64   `default:"20" flag:"gas-margin" info:"Percentage added to the estimated computation when the gas limit is set automatically"`
+	WaitFor     string   `default:"sealed" flag:"wait-for" info:"Transaction status to wait for. Valid values: finalized, executed, sealed."`
+	DryRun      bool     `default:"false" flag:"dry-run" info:"Simulate the transaction in an emulator seeded with the used accounts without sending it"`
 }
 
 var flags = Flags{}
@@ -75,81 +80,161 @@ func send(
 }
 
 func SendTransaction(code []byte, args []string, location string, flow flowkit.Services, state *flowkit.State, sendFlags Flags) (result command.Result, err error) {
-	proposerName := sendFlags.Proposer
+	roles, err := resolveRoles(state, sendFlags.Signer, sendFlags.Proposer, sendFlags.Payer, sendFlags.Authorizers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setWaitStrategy(flow, sendFlags.WaitFor)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionArgs []cadence.Value
+	if sendFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args, code, location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	script := flowkit.Script{Code: code, Args: transactionArgs, Location: location}
+
+	gasLimit, estimate, err := resolveGasLimit(flow, roles, script, sendFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	if sendFlags.DryRun {
+		tx, simulation, err := flow.SimulateTransaction(context.Background(), roles, script, gasLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		return &transactionResult{
+			result:     simulation.Result,
+			simulation: simulation,
+			estimate:   estimate,
+			tx:         tx,
+			include:    sendFlags.Include,
+			exclude:    sendFlags.Exclude,
+		}, nil
+	}
+
+	tx, txResult, err := flow.SendTransaction(context.Background(), roles, script, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResult{
+		result:   txResult,
+		estimate: estimate,
+		tx:       tx,
+		include:  sendFlags.Include,
+		exclude:  sendFlags.Exclude,
+	}, nil
+}
+
+// resolveRoles looks up the accounts used for each transaction role in the configuration.
+//
+// The signer is used as proposer, payer and authorizer and can't be combined with the other roles,
+// if no account is provided the emulator service account is used as signer.
+func resolveRoles(
+	state *flowkit.State,
+	signerName string,
+	proposerName string,
+	payerName string,
+	authorizerNames []string,
+) (transactions.AccountRoles, error) {
 	var proposer *accounts.Account
+	var err error
 	if proposerName != "" {
 		proposer, err = state.Accounts().ByName(proposerName)
 		if err != nil {
-			return nil, fmt.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
+			return transactions.AccountRoles{}, fmt.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
 		}
 	}
 
-	payerName := sendFlags.Payer
 	var payer *accounts.Account
 	if payerName != "" {
 		payer, err = state.Accounts().ByName(payerName)
 		if err != nil {
-			return nil, fmt.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
+			return transactions.AccountRoles{}, fmt.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
 		}
 	}
 
 	var authorizers []accounts.Account
-	for _, authorizerName := range sendFlags.Authorizers {
+	for _, authorizerName := range authorizerNames {
 		authorizer, err := state.Accounts().ByName(authorizerName)
 		if err != nil {
-			return nil, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
+			return transactions.AccountRoles{}, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
 		}
 		authorizers = append(authorizers, *authorizer)
 	}
 
-	signerName := sendFlags.Signer
-
 	if signerName == "" && proposer == nil && payer == nil && len(authorizers) == 0 {
 		signerName = state.Config().Emulators.Default().ServiceAccount
 	}
 
 	if signerName != "" {
 		if proposer != nil || payer != nil || len(authorizers) > 0 {
-			return nil, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
+			return transactions.AccountRoles{}, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
 		}
 		signer, err := state.Accounts().ByName(signerName)
 		if err != nil {
-			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+			return transactions.AccountRoles{}, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
 		proposer = signer
 		payer = signer
 		authorizers = append(authorizers, *signer)
 	}
 
-	var transactionArgs []cadence.Value
-	if sendFlags.ArgsJSON != "" {
-		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
-	} else {
-		transactionArgs, err = arguments.ParseWithoutType(args, code, location)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	if proposer == nil || payer == nil {
+		return transactions.AccountRoles{}, fmt.Errorf("proposer and payer accounts must be provided")
 	}
 
-	tx, txResult, err := flow.SendTransaction(
-		context.Background(),
-		transactions.AccountRoles{
-			Proposer:    *proposer,
-			Authorizers: authorizers,
-			Payer:       *payer,
-		},
-		flowkit.Script{Code: code, Args: transactionArgs, Location: location},
-		sendFlags.GasLimit,
-	)
+	return transactions.AccountRoles{
+		Proposer:    *proposer,
+		Authorizers: authorizers,
+		Payer:       *payer,
+	}, nil
+}
+
+// minAutoGasLimit is the lowest gas limit set from the estimate, so transactions using almost no computation can still be sent.
+const minAutoGasLimit = 10
+
+// autoGasLimit is the gas limit flag value which sets the gas limit from the estimated computation.
+const autoGasLimit = "auto"
 
+// resolveGasLimit returns the gas limit flag, or when the gas limit is auto the estimated computation plus the margin percentage.
+func resolveGasLimit(
+	flow flowkit.Services,
+	roles transactions.AccountRoles,
+	script flowkit.Script,
+	sendFlags Flags,
+) (uint64, *flowkit.TransactionEstimate, error) {
+	if sendFlags.GasLimit != autoGasLimit {
+		gasLimit, err := strconv.ParseUint(sendFlags.GasLimit, 10, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid gas limit %s, must be a number or %s", sendFlags.GasLimit, autoGasLimit)
+		}
+		return gasLimit, nil, nil
+	}
+
+	estimate, err := flow.EstimateTransaction(context.Background(), roles, script)
 	if err != nil {
-		return nil, err
+		return 0, nil, fmt.Errorf("failed to estimate the gas limit, set a numeric gas limit instead: %w", err)
 	}
 
-	return &transactionResult{
-		result:  txResult,
-		tx:      tx,
-		include: sendFlags.Include,
-		exclude: sendFlags.Exclude,
-	}, nil
+	gasLimit := estimate.ComputationUsed + (estimate.ComputationUsed*sendFlags.GasMargin+99)/100
+	if gasLimit < minAutoGasLimit {
+		gasLimit = minAutoGasLimit
+	}
+	if gasLimit > flowsdk.DefaultTransactionGasLimit {
+		gasLimit = flowsdk.DefaultTransactionGasLimit
+	}
+
+	return gasLimit, estimate, nil
 }
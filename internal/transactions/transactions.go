@@ -26,6 +26,8 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
@@ -42,17 +44,35 @@ var Cmd = &cobra.Command{
 func init() {
 	getCommand.AddToParent(Cmd)
 	sendCommand.AddToParent(Cmd)
+	sendBatchCommand.AddToParent(Cmd)
 	signCommand.AddToParent(Cmd)
 	buildCommand.AddToParent(Cmd)
 	sendSignedCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
 }
 
+// setWaitStrategy sets the transaction status to wait for, the default strategy is kept if no status is provided.
+func setWaitStrategy(flow flowkit.Services, status string) error {
+	if status == "" {
+		return nil
+	}
+
+	strategy, err := flowkit.NewWaitStrategy(status)
+	if err != nil {
+		return err
+	}
+
+	flow.SetWaitStrategy(strategy)
+	return nil
+}
+
 type transactionResult struct {
-	result  *flow.TransactionResult
-	tx      *flow.Transaction
-	include []string
-	exclude []string
+	result     *flow.TransactionResult
+	simulation *gateway.SimulationResult
+	estimate   *flowkit.TransactionEstimate
+	tx         *flow.Transaction
+	include    []string
+	exclude    []string
 }
 
 func (r *transactionResult) JSON() any {
@@ -84,6 +104,20 @@ func (r *transactionResult) JSON() any {
 		}
 	}
 
+	if r.simulation != nil {
+		// the simulated block doesn't exist on the network
+		delete(result, "block_id")
+		delete(result, "block_height")
+		result["simulated"] = true
+		result["computation_used"] = r.simulation.ComputationUsed
+		result["logs"] = r.simulation.Logs
+	}
+
+	if r.estimate != nil {
+		result["estimated_computation"] = r.estimate.ComputationUsed
+		result["estimated_fee"] = r.estimate.Fee.String()
+	}
+
 	return result
 }
 
@@ -92,8 +126,10 @@ func (r *transactionResult) String() string {
 	writer := util.CreateTabWriter(&b)
 
 	if r.result != nil {
-		_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.result.BlockID)
-		_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.result.BlockHeight)
+		if r.simulation == nil {
+			_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.result.BlockID)
+			_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.result.BlockHeight)
+		}
 		if r.result.Error != nil {
 			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n\n", output.ErrorEmoji(), r.result.Error.Error())
 		}
@@ -105,6 +141,17 @@ func (r *transactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "Status\t%s %s\n", statusBadge, r.result.Status)
 	}
 
+	if r.simulation != nil {
+		_, _ = fmt.Fprintf(writer, "Simulated\tYes, the transaction was not sent\n")
+		_, _ = fmt.Fprintf(writer, "Computation Used\t%d\n", r.simulation.ComputationUsed)
+	}
+
+	if r.estimate != nil {
+		_, _ = fmt.Fprintf(writer, "Estimated Computation\t%d\n", r.estimate.ComputationUsed)
+		_, _ = fmt.Fprintf(writer, "Estimated Fee\t%s\n", r.estimate.Fee)
+		_, _ = fmt.Fprintf(writer, "Gas Limit\t%d\n", r.tx.GasLimit)
+	}
+
 	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
 	_, _ = fmt.Fprintf(writer, "Payer\t%s\n", r.tx.Payer.Hex())
 	_, _ = fmt.Fprintf(writer, "Authorizers\t%s\n", r.tx.Authorizers)
@@ -161,6 +208,13 @@ func (r *transactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "\n\nEvents:\t %s\n", eventsOutput)
 	}
 
+	if r.simulation != nil && len(r.simulation.Logs) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\n")
+		for _, log := range r.simulation.Logs {
+			_, _ = fmt.Fprintf(writer, "    %s\n", log)
+		}
+	}
+
 	if r.tx.Script != nil {
 		if command.ContainsFlag(r.include, "code") {
 			if len(r.tx.Arguments) == 0 {
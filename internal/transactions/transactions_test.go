@@ -20,6 +20,7 @@ package transactions
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
@@ -133,6 +135,30 @@ func Test_Get(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+
+	t.Run("Success wait for executed", func(t *testing.T) {
+		inArgs := []string{"0x01"}
+		getFlags.WaitFor = "executed"
+
+		srv.SetWaitStrategy.Run(func(args mock.Arguments) {
+			strategy := args.Get(0).(flowkit.WaitStrategy)
+			assert.Equal(t, flow.TransactionStatusExecuted, strategy.Status)
+		})
+		srv.GetTransactionByID.Return(nil, nil, nil)
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		srv.Mock.AssertCalled(t, "SetWaitStrategy", mock.Anything)
+		getFlags.WaitFor = "" // reset
+	})
+
+	t.Run("Fail invalid wait status", func(t *testing.T) {
+		getFlags.WaitFor = "pending"
+		_, err := get([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, `invalid transaction status: pending, valid are: "finalized", "executed" or "sealed"`)
+		getFlags.WaitFor = "" // reset
+	})
 }
 
 func Test_Send(t *testing.T) {
@@ -140,7 +166,7 @@ func Test_Send(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		const gas = uint64(1000)
-		flags.GasLimit = gas
+		flags.GasLimit = "1000"
 		inArgs := []string{tests.TransactionArgString.Filename}
 
 		srv.SendTransaction.Run(func(args mock.Arguments) {
@@ -159,6 +185,59 @@ func Test_Send(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success dry run", func(t *testing.T) {
+		flags.DryRun = true
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		srv.SimulateTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, tests.TransactionArgString.Filename, script.Location)
+		})
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Equal(t, true, result.JSON().(map[string]any)["simulated"])
+		srv.Mock.AssertNumberOfCalls(t, "SimulateTransaction", 1)
+		flags.DryRun = false // reset
+	})
+
+	t.Run("Success auto gas limit", func(t *testing.T) {
+		flags.GasLimit = "auto"
+		flags.GasMargin = 20
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			// the mocked estimate uses 100 computation
+			assert.Equal(t, uint64(120), args.Get(3).(uint64))
+		}).Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100), result.JSON().(map[string]any)["estimated_computation"])
+		flags.GasLimit = "1000" // reset
+	})
+
+	t.Run("Fail invalid gas limit", func(t *testing.T) {
+		flags.GasLimit = "invalid"
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		_, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid gas limit invalid, must be a number or auto")
+		flags.GasLimit = "1000" // reset
+	})
+
+	t.Run("Fail auto gas limit", func(t *testing.T) {
+		flags.GasLimit = "auto"
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		srv.EstimateTransaction.Return(nil, fmt.Errorf("address is not valid on any known chain"))
+
+		_, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "failed to estimate the gas limit, set a numeric gas limit instead: address is not valid on any known chain")
+		srv.EstimateTransaction.Return(&flowkit.TransactionEstimate{ComputationUsed: 100, Fee: 1000}, nil)
+		flags.GasLimit = "1000" // reset
+	})
+
 	t.Run("Fail non-existing account", func(t *testing.T) {
 		flags.Proposer = "invalid"
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
@@ -230,6 +309,93 @@ func Test_SendSigned(t *testing.T) {
 	})
 }
 
+func Test_SendBatch(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		manifest := []byte(`
+transactions:
+  - name: greet
+    file: transactionArg.cdc
+    args: ["Hello"]
+    gasLimit: 500
+  - file: transactionSimple.cdc
+    signer: emulator-account
+`)
+		_ = rw.WriteFile("manifest.yaml", manifest, 0677)
+		sendBatchFlags.Concurrency = 2
+
+		srv.SendTransactions.Run(func(args mock.Arguments) {
+			txs := args.Get(1).([]flowkit.BatchTransaction)
+			assert.Len(t, txs, 2)
+			assert.Equal(t, tests.TransactionArgString.Filename, txs[0].Script.Location)
+			assert.Equal(t, cadence.String("Hello"), txs[0].Script.Args[0])
+			assert.Equal(t, uint64(500), txs[0].GasLimit)
+			assert.Equal(t, config.DefaultEmulator.ServiceAccount, txs[0].Accounts.Proposer.Name)
+			assert.Equal(t, tests.TransactionSimple.Filename, txs[1].Script.Location)
+			assert.Equal(t, uint64(1000), txs[1].GasLimit)
+			assert.Equal(t, 2, args.Get(2).(int))
+
+			results := []flowkit.BatchResult{
+				{Tx: tests.NewTransaction(), Result: tests.NewTransactionResult(nil)},
+				{Error: fmt.Errorf("failed to send")},
+			}
+			srv.SendTransactions.Return(results, nil)
+		})
+
+		result, err := sendBatch([]string{"manifest.yaml"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+
+		jsonResult := result.JSON().([]any)
+		assert.Len(t, jsonResult, 2)
+		assert.Equal(t, "greet", jsonResult[0].(map[string]any)["name"])
+		assert.NotContains(t, jsonResult[0].(map[string]any), "error")
+		assert.Equal(t, "failed to send", jsonResult[1].(map[string]any)["error"])
+		assert.Equal(t, "Sent: 2, Failed: 1", result.Oneliner())
+		sendBatchFlags.Concurrency = 1 // reset
+	})
+
+	t.Run("Success JSON manifest", func(t *testing.T) {
+		manifest := []byte(`{"transactions": [{"file": "transactionArg.cdc", "argsJSON": "[{\"type\": \"String\", \"value\": \"Hi\"}]"}]}`)
+		_ = rw.WriteFile("manifest.json", manifest, 0677)
+
+		srv.SendTransactions.Run(func(args mock.Arguments) {
+			txs := args.Get(1).([]flowkit.BatchTransaction)
+			assert.Equal(t, cadence.String("Hi"), txs[0].Script.Args[0])
+			srv.SendTransactions.Return([]flowkit.BatchResult{{Tx: tests.NewTransaction()}}, nil)
+		})
+
+		_, err := sendBatch([]string{"manifest.json"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail unknown manifest field", func(t *testing.T) {
+		_ = rw.WriteFile("manifest.yaml", []byte("transactions:\n  - script: transactionSimple.cdc\n"), 0677)
+
+		_, err := sendBatch([]string{"manifest.yaml"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "error parsing manifest file")
+	})
+
+	t.Run("Fail empty manifest", func(t *testing.T) {
+		_ = rw.WriteFile("manifest.yaml", []byte("transactions: []\n"), 0677)
+
+		_, err := sendBatch([]string{"manifest.yaml"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "manifest file manifest.yaml doesn't list any transactions")
+	})
+
+	t.Run("Fail invalid account", func(t *testing.T) {
+		_ = rw.WriteFile("manifest.yaml", []byte("transactions:\n  - name: setup\n    file: transactionSimple.cdc\n    signer: invalid\n"), 0677)
+
+		_, err := sendBatch([]string{"manifest.yaml"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid transaction setup: signer account: [invalid] doesn't exists in configuration")
+	})
+
+	t.Run("Fail loading manifest", func(t *testing.T) {
+		_, err := sendBatch([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "error loading manifest file: open invalid: file does not exist")
+	})
+}
+
 func Test_Sign(t *testing.T) {
 	srv, state, rw := util.TestMocks(t)
 
@@ -391,4 +557,56 @@ Payload (hidden, use --include payload)`, "\n"), result.String())
 			"status":  "SEALED",
 		}, result.JSON())
 	})
+
+	t.Run("Success with simulation", func(t *testing.T) {
+		simulation := &gateway.SimulationResult{
+			Result: &flow.TransactionResult{
+				Status:      flow.TransactionStatusExecuted,
+				BlockID:     flow.HexToID("7aa74143741c1c3b837d389fcffa7a5e251b67b4ffef6d6887b40cd9c803f537"),
+				BlockHeight: 1,
+			},
+			ComputationUsed: 42,
+			Logs:            []string{"hello"},
+		}
+		result := transactionResult{tx: tx, result: simulation.Result, simulation: simulation}
+
+		assert.Equal(t, strings.TrimPrefix(`
+Status			 EXECUTED
+Simulated		Yes, the transaction was not sent
+Computation Used	42
+ID			e913d1f3e431c7df49c99845bea9ebff9db11bbf25d507b9ad0fad45652d515f
+Payer			0000000000000002
+Authorizers		[]
+
+Proposal Key:	
+    Address	0000000000000001
+    Index	0
+    Sequence	1
+
+Payload Signature 0: 0000000000000001
+Envelope Signature 0: 0000000000000001
+Signatures (minimized, use --include signatures)
+
+Events:	 None
+
+Logs:
+    hello
+
+
+Code (hidden, use --include code)
+
+Payload (hidden, use --include payload)`, "\n"), result.String())
+
+		assert.Equal(t, map[string]any{
+			"authorizers":      "[]",
+			"computation_used": uint64(42),
+			"events":           []any{},
+			"id":               "e913d1f3e431c7df49c99845bea9ebff9db11bbf25d507b9ad0fad45652d515f",
+			"logs":             []string{"hello"},
+			"payer":            "0000000000000002",
+			"payload":          "f8dbf8498e7472616e73616374696f6e207b7dc0a06cde7f812897d22ee7633b82b059070be24faccdc47997bc0f765420e6e28bb682270f8800000000000000018001880000000000000002c0f846f8448080b84036636465376638313238393764323265653736333362383262303539303730626532346661636364633437393937626330663736353432306536653238626236f846f8448080b84036636465376638313238393764323265653736333362383262303539303730626532346661636364633437393937626330663736353432306536653238626236",
+			"simulated":        true,
+			"status":           "EXECUTED",
+		}, result.JSON())
+	})
 }